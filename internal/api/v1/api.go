@@ -2,8 +2,28 @@ package api
 
 import (
 	"net/http"
+	"time"
 
+	assetrepo "github.com/alan-b-lima/prp/internal/domain/asset/repository"
+	assets "github.com/alan-b-lima/prp/internal/domain/asset/resource"
+	"github.com/alan-b-lima/prp/internal/domain/book"
+	bookrepo "github.com/alan-b-lima/prp/internal/domain/book/repository"
+	books "github.com/alan-b-lima/prp/internal/domain/book/resource"
+	grouprepo "github.com/alan-b-lima/prp/internal/domain/group/repository"
+	groups "github.com/alan-b-lima/prp/internal/domain/group/resource"
+	invoicerepo "github.com/alan-b-lima/prp/internal/domain/invoice/repository"
+	invoices "github.com/alan-b-lima/prp/internal/domain/invoice/resource"
+	loanrepo "github.com/alan-b-lima/prp/internal/domain/loan/repository"
+	loans "github.com/alan-b-lima/prp/internal/domain/loan/resource"
+	"github.com/alan-b-lima/prp/internal/domain/notification"
+	"github.com/alan-b-lima/prp/internal/domain/notification/notifier"
+	notificationrepo "github.com/alan-b-lima/prp/internal/domain/notification/repository"
+	notifications "github.com/alan-b-lima/prp/internal/domain/notification/resource"
+	payeerepo "github.com/alan-b-lima/prp/internal/domain/payee/repository"
+	payees "github.com/alan-b-lima/prp/internal/domain/payee/resource"
 	sessionrepo "github.com/alan-b-lima/prp/internal/domain/session/repository"
+	tagrepo "github.com/alan-b-lima/prp/internal/domain/tag/repository"
+	tags "github.com/alan-b-lima/prp/internal/domain/tag/resource"
 	userrepo "github.com/alan-b-lima/prp/internal/domain/user/repository"
 	users "github.com/alan-b-lima/prp/internal/domain/user/resource"
 )
@@ -16,10 +36,46 @@ func New() http.Handler {
 	var (
 		sessionsRepo = sessionrepo.NewMap()
 		usersRepo    = userrepo.NewMap()
+		booksRepo    = bookrepo.NewMap()
+		payeesRepo   = payeerepo.NewMap()
+		tagsRepo     = tagrepo.NewMap()
+		loansRepo    = loanrepo.NewMap()
+		groupsRepo   = grouprepo.NewMap()
+		assetsRepo   = assetrepo.NewMap()
+		invoicesRepo = invoicerepo.NewMap()
+		notifsRepo   = notificationrepo.NewMap()
 	)
 
+	notifiers := map[notification.Channel]notification.Notifier{
+		notification.Inbox:   notifier.NewInbox(notifsRepo),
+		notification.Email:   notifier.NewSMTP("localhost:1025", "prp@localhost"),
+		notification.Webhook: notifier.NewWebhook(10 * time.Second),
+	}
+
 	users := users.New(usersRepo, sessionsRepo)
+	books := books.New(booksRepo, map[string]book.Counter{
+		"payees":   payeesRepo,
+		"tags":     tagsRepo,
+		"loans":    loansRepo,
+		"assets":   assetsRepo,
+		"invoices": invoicesRepo,
+	}, usersRepo, sessionsRepo)
+	payees := payees.New(payeesRepo, booksRepo, usersRepo, sessionsRepo)
+	tags := tags.New(tagsRepo, booksRepo, usersRepo, sessionsRepo)
+	loans := loans.New(loansRepo, booksRepo, usersRepo, sessionsRepo)
+	assets := assets.New(assetsRepo, booksRepo, usersRepo, sessionsRepo)
+	invoices := invoices.New(invoicesRepo, booksRepo, usersRepo, sessionsRepo)
+	groups := groups.New(groupsRepo, usersRepo, sessionsRepo)
+	notifications := notifications.New(notifsRepo, notifiers, usersRepo, sessionsRepo)
 
 	r.Handle("/api/v1/users/", http.StripPrefix("/api/v1", users))
+	r.Handle("/api/v1/books/", http.StripPrefix("/api/v1", books))
+	r.Handle("/api/v1/books/{book}/payees/", http.StripPrefix("/api/v1", payees))
+	r.Handle("/api/v1/books/{book}/tags/", http.StripPrefix("/api/v1", tags))
+	r.Handle("/api/v1/books/{book}/loans/", http.StripPrefix("/api/v1", loans))
+	r.Handle("/api/v1/books/{book}/assets/", http.StripPrefix("/api/v1", assets))
+	r.Handle("/api/v1/books/{book}/invoices/", http.StripPrefix("/api/v1", invoices))
+	r.Handle("/api/v1/groups/", http.StripPrefix("/api/v1", groups))
+	r.Handle("/api/v1/notifications/", http.StripPrefix("/api/v1", notifications))
 	return &r
 }
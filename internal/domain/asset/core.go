@@ -0,0 +1,142 @@
+package asset
+
+import (
+	"github.com/alan-b-lima/prp/pkg/depreciation"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/period"
+)
+
+func List(assets Lister, req ListRequest) (ListResponse, error) {
+	res, err := assets.List(req.Book, req.Offset, req.Limit)
+	if err != nil {
+		return ListResponse{}, err
+	}
+
+	ares := ListResponse{
+		Offset:       res.Offset,
+		Length:       res.Length,
+		Records:      make([]Response, res.Length),
+		TotalRecords: res.TotalRecords,
+	}
+	for i := 0; i < res.Length; i++ {
+		transform(&ares.Records[i], &res.Records[i])
+	}
+
+	return ares, nil
+}
+
+func Get(assets Getter, req GetRequest) (Response, error) {
+	res, err := assets.Get(req.UUID)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Create(assets Creater, req CreateRequest) (Response, error) {
+	res, err := assets.Create(req.Book, req.Name, req.Cost, req.Residual, req.Life, req.Method, req.Factor, req.Acquired)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Patch(assets Patcher, req PatchRequest) (Response, error) {
+	res, err := assets.Patch(req.UUID, req.Name)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Delete(assets Deleter, req DeleteRequest) error {
+	return assets.Delete(req.UUID)
+}
+
+// Schedule lists the monthly depreciation of the asset, the schedule
+// stops at the disposal, if any.
+func Schedule(assets Getter, req ScheduleRequest) (ScheduleResponse, error) {
+	res, err := assets.Get(req.UUID)
+	if err != nil {
+		return ScheduleResponse{}, err
+	}
+
+	rows, err := depreciation.Schedule(depreciation.Asset{
+		Cost:     res.Cost,
+		Residual: res.Residual,
+		Life:     res.Life,
+		Method:   res.Method,
+		Factor:   res.Factor,
+	})
+	if err != nil {
+		return ScheduleResponse{}, err
+	}
+
+	if disposal, ok := res.Disposal.Unwrap(); ok {
+		rows = rows[:min(MonthsHeld(res.Acquired, disposal.Date), len(rows))]
+	}
+
+	ares := ScheduleResponse{
+		Asset:   res.UUID,
+		Periods: make([]PeriodResponse, len(rows)),
+	}
+	acquired := period.DateOf(res.Acquired)
+	for i, row := range rows {
+		// assets acquired at the end of a month depreciate at the end
+		// of the shorter months
+		date := acquired.AddMonths(row.Period).Time(res.Acquired.Location())
+
+		ares.Periods[i] = PeriodResponse{
+			Period:       row.Period,
+			Date:         date,
+			Depreciation: row.Depreciation,
+			Accumulated:  row.Accumulated,
+			BookValue:    row.BookValue,
+		}
+
+		ares.TotalDepreciation += row.Depreciation
+	}
+
+	return ares, nil
+}
+
+func Dispose(assets Disposer, req DisposeRequest) (Response, error) {
+	res, err := assets.Dispose(req.UUID, req.Date, req.Proceeds)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func transform(r *Response, e *Entity) {
+	r.UUID = e.UUID
+	r.Book = e.Book
+	r.Name = e.Name
+	r.Cost = e.Cost
+	r.Residual = e.Residual
+	r.Life = e.Life
+	r.Method = e.Method.String()
+	r.Factor = e.Factor
+	r.Acquired = e.Acquired
+
+	if disposal, ok := e.Disposal.Unwrap(); ok {
+		r.Disposal = opt.Some(DisposalResponse{
+			Date:      disposal.Date,
+			Proceeds:  disposal.Proceeds,
+			BookValue: disposal.BookValue,
+			Gain:      disposal.Gain,
+		})
+	}
+}
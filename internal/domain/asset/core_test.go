@@ -0,0 +1,79 @@
+package asset_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/alan-b-lima/prp/internal/domain/asset"
+	assetrepo "github.com/alan-b-lima/prp/internal/domain/asset/repository"
+	"github.com/alan-b-lima/prp/pkg/depreciation"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func TestScheduleMonthEnd(t *testing.T) {
+	assets := assetrepo.NewMap()
+
+	acquired := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	a, err := assets.Create(uuid.NewUUIDv7(), "laptop", 1200000, 0, 4, "straight-line", 0, acquired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := Schedule(assets, ScheduleRequest{Book: a.Book, UUID: a.UUID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dates := []string{"2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31"}
+	if len(res.Periods) != len(dates) {
+		t.Fatalf("expected %d periods, got %d", len(dates), len(res.Periods))
+	}
+
+	for i, p := range res.Periods {
+		if got := p.Date.Format(time.DateOnly); got != dates[i] {
+			t.Errorf("period %d should be on %s, got %s", p.Period, dates[i], got)
+		}
+	}
+}
+
+func TestMonthsHeld(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     int
+	}{
+		{"2026-01-15", "2026-01-31", 0},
+		{"2026-01-15", "2026-02-14", 0},
+		{"2026-01-15", "2026-02-15", 1},
+		{"2026-01-31", "2026-02-27", 0},
+		{"2026-01-31", "2026-02-28", 1},
+		{"2026-01-31", "2026-03-30", 1},
+		{"2026-01-31", "2026-03-31", 2},
+		{"2024-02-29", "2025-02-28", 12},
+		{"2026-03-01", "2026-01-01", 0},
+	}
+
+	for _, test := range tests {
+		from, _ := time.Parse(time.DateOnly, test.from)
+		to, _ := time.Parse(time.DateOnly, test.to)
+
+		if got := MonthsHeld(from, to); got != test.want {
+			t.Errorf("from %s to %s: expected %d months, got %d", test.from, test.to, test.want, got)
+		}
+	}
+}
+
+func TestLifeBound(t *testing.T) {
+	for _, life := range []int{0, depreciation.MaxLife + 1, 1 << 40} {
+		if _, err := ProcessLife(life); !errors.Is(err, depreciation.ErrBadLife) {
+			t.Errorf("life %d should have been rejected, got %v", life, err)
+		}
+	}
+
+	assets := assetrepo.NewMap()
+
+	acquired := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := assets.Create(uuid.NewUUIDv7(), "building", 1000000, 0, 1<<40, "straight-line", 0, acquired); err == nil {
+		t.Error("asset with an unbounded life should not have been created")
+	}
+}
@@ -0,0 +1,192 @@
+package asset
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/depreciation"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/period"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Asset struct {
+	uuid     uuid.UUID
+	book     uuid.UUID
+	name     string
+	cost     int64
+	residual int64
+	life     int
+	method   depreciation.Method
+	factor   float64
+	acquired time.Time
+	disposal *Disposal
+}
+
+type Disposal struct {
+	Date      time.Time
+	Proceeds  int64
+	BookValue int64
+	Gain      int64 // negative for a loss
+}
+
+func New(book uuid.UUID, name string, cost, residual int64, life int, method string, factor float64, acquired time.Time) (Asset, error) {
+	a := Asset{book: book}
+
+	err := errors.Join(
+		a.SetName(name),
+		set(&a.cost, cost, ProcessCost),
+		set(&a.residual, residual, a.processResidual),
+		set(&a.life, life, ProcessLife),
+		set(&a.method, method, ProcessMethod),
+		set(&a.factor, factor, ProcessFactor),
+		set(&a.acquired, acquired, ProcessAcquired),
+	)
+	if err != nil {
+		return Asset{}, xerrors.ErrAssetCreation.New(err)
+	}
+
+	a.uuid = uuid.NewUUIDv7()
+	return a, nil
+}
+
+func (a *Asset) UUID() uuid.UUID             { return a.uuid }
+func (a *Asset) Book() uuid.UUID             { return a.book }
+func (a *Asset) Name() string                { return a.name }
+func (a *Asset) Cost() int64                 { return a.cost }
+func (a *Asset) Residual() int64             { return a.residual }
+func (a *Asset) Life() int                   { return a.life }
+func (a *Asset) Method() depreciation.Method { return a.method }
+func (a *Asset) Factor() float64             { return a.factor }
+func (a *Asset) Acquired() time.Time         { return a.acquired }
+
+func (a *Asset) Disposal() (Disposal, bool) {
+	if a.disposal == nil {
+		return Disposal{}, false
+	}
+
+	return *a.disposal, true
+}
+
+func (a *Asset) Depreciation() depreciation.Asset {
+	return depreciation.Asset{
+		Cost:     a.cost,
+		Residual: a.residual,
+		Life:     a.life,
+		Method:   a.method,
+		Factor:   a.factor,
+	}
+}
+
+func (a *Asset) SetName(name string) error { return set(&a.name, name, ProcessName) }
+
+// Dispose records the sale or write-off of the asset, the gain or
+// loss is the difference between the proceeds and the book value at
+// the date, depreciated for every full month held.
+func (a *Asset) Dispose(date time.Time, proceeds int64) error {
+	if a.disposal != nil {
+		return xerrors.ErrAssetDisposed
+	}
+
+	if date.IsZero() || date.Before(a.acquired) || proceeds < 0 {
+		return xerrors.ErrBadDisposal
+	}
+
+	value, err := depreciation.BookValue(a.Depreciation(), MonthsHeld(a.acquired, date))
+	if err != nil {
+		return xerrors.ErrBadAssetTerms.New(err)
+	}
+
+	a.disposal = &Disposal{
+		Date:      date,
+		Proceeds:  proceeds,
+		BookValue: value,
+		Gain:      proceeds - value,
+	}
+	return nil
+}
+
+// MonthsHeld is the amount of full months between from and to,
+// months are counted as in [period.Date.AddMonths], so an asset
+// acquired on January 31 is held for a full month on the end of
+// February.
+func MonthsHeld(from, to time.Time) int {
+	start, end := period.DateOf(from), period.DateOf(to)
+
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	if start.AddMonths(months).After(end) {
+		months--
+	}
+
+	return max(months, 0)
+}
+
+func ProcessName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", xerrors.ErrNameEmpty
+	}
+
+	return name, nil
+}
+
+func ProcessCost(cost int64) (int64, error) {
+	if cost <= 0 {
+		return 0, xerrors.ErrBadAssetTerms.New(depreciation.ErrBadCost)
+	}
+
+	return cost, nil
+}
+
+func (a *Asset) processResidual(residual int64) (int64, error) {
+	if residual < 0 || residual > a.cost {
+		return 0, xerrors.ErrBadAssetTerms.New(depreciation.ErrBadResidual)
+	}
+
+	return residual, nil
+}
+
+func ProcessLife(life int) (int, error) {
+	if life <= 0 || life > depreciation.MaxLife {
+		return 0, xerrors.ErrBadAssetTerms.New(depreciation.ErrBadLife)
+	}
+
+	return life, nil
+}
+
+func ProcessMethod(method string) (depreciation.Method, error) {
+	m, err := depreciation.ParseMethod(method)
+	if err != nil {
+		return 0, xerrors.ErrBadAssetTerms.New(err)
+	}
+
+	return m, nil
+}
+
+func ProcessFactor(factor float64) (float64, error) {
+	if !(factor >= 0) || math.IsInf(factor, 1) {
+		return 0, xerrors.ErrBadAssetTerms.New(depreciation.ErrBadFactor)
+	}
+
+	return factor, nil
+}
+
+func ProcessAcquired(acquired time.Time) (time.Time, error) {
+	if acquired.IsZero() {
+		return time.Time{}, xerrors.ErrAcquiredEmpty
+	}
+
+	return acquired, nil
+}
+
+func set[T, R any](dst *R, src T, fn func(T) (R, error)) error {
+	val, err := fn(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = val
+	return nil
+}
@@ -0,0 +1,70 @@
+package asset
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/depreciation"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Repository interface {
+	Lister
+	Counter
+	Getter
+	Creater
+	Patcher
+	Deleter
+	Disposer
+}
+
+type Lister interface {
+	List(book uuid.UUID, offset, limit int) (ListEntity, error)
+}
+
+// Counter counts the assets of a book.
+type Counter interface {
+	Count(book uuid.UUID) (int, error)
+}
+
+type Getter interface {
+	Get(uuid uuid.UUID) (Entity, error)
+}
+
+type Creater interface {
+	Create(book uuid.UUID, name string, cost, residual int64, life int, method string, factor float64, acquired time.Time) (Entity, error)
+}
+
+type Patcher interface {
+	Patch(uuid uuid.UUID, name opt.Opt[string]) (Entity, error)
+}
+
+type Deleter interface {
+	Delete(uuid uuid.UUID) error
+}
+
+type Disposer interface {
+	Dispose(uuid uuid.UUID, date time.Time, proceeds int64) (Entity, error)
+}
+
+type Entity struct {
+	UUID     uuid.UUID
+	Book     uuid.UUID
+	Name     string
+	Cost     int64
+	Residual int64
+	Life     int
+	Method   depreciation.Method
+	Factor   float64
+	Acquired time.Time
+	Disposal opt.Opt[Disposal]
+}
+
+func (e Entity) InBook() uuid.UUID { return e.Book }
+
+type ListEntity struct {
+	Offset       int
+	Length       int
+	Records      []Entity
+	TotalRecords int
+}
@@ -0,0 +1,196 @@
+package assetrepo
+
+import (
+	"cmp"
+	"sync"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/domain/asset"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Map struct {
+	uuidIndex map[uuid.UUID]int
+
+	repo []asset.Asset
+	mu   sync.RWMutex
+}
+
+func NewMap() asset.Repository {
+	repo := Map{
+		uuidIndex: make(map[uuid.UUID]int),
+	}
+
+	return &repo
+}
+
+func (m *Map) List(book uuid.UUID, offset, limit int) (asset.ListEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var scoped []*asset.Asset
+	for i := range m.repo {
+		if m.repo[i].Book() == book {
+			scoped = append(scoped, &m.repo[i])
+		}
+	}
+
+	lo := clamp(0, offset, len(scoped))
+	hi := clamp(0, offset+limit, len(scoped))
+
+	if lo >= hi {
+		return asset.ListEntity{TotalRecords: len(scoped)}, nil
+	}
+
+	res := make([]asset.Entity, hi-lo)
+	for i, a := range scoped[lo:hi] {
+		transform(&res[i], a)
+	}
+
+	return asset.ListEntity{
+		Offset:       lo,
+		Length:       len(res),
+		Records:      res,
+		TotalRecords: len(scoped),
+	}, nil
+}
+
+func (m *Map) Count(book uuid.UUID) (int, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var count int
+	for i := range m.repo {
+		if m.repo[i].Book() == book {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (m *Map) Get(uuid uuid.UUID) (asset.Entity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return asset.Entity{}, xerrors.ErrAssetNotFound
+	}
+
+	var res asset.Entity
+	transform(&res, &m.repo[index])
+	return res, nil
+}
+
+func (m *Map) Create(book uuid.UUID, name string, cost, residual int64, life int, method string, factor float64, acquired time.Time) (asset.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	a, err := asset.New(book, name, cost, residual, life, method, factor, acquired)
+	if err != nil {
+		return asset.Entity{}, err
+	}
+
+	m.uuidIndex[a.UUID()] = len(m.repo)
+	m.repo = append(m.repo, a)
+
+	var res asset.Entity
+	transform(&res, &a)
+	return res, nil
+}
+
+func (m *Map) Patch(uuid uuid.UUID, name opt.Opt[string]) (asset.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return asset.Entity{}, xerrors.ErrAssetNotFound
+	}
+
+	a := m.repo[index]
+
+	if err := some_then(name, a.SetName); err != nil {
+		return asset.Entity{}, err
+	}
+
+	m.repo[index] = a
+
+	var res asset.Entity
+	transform(&res, &a)
+	return res, nil
+}
+
+func (m *Map) Delete(uuid uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return nil
+	}
+
+	delete(m.uuidIndex, uuid)
+
+	last := len(m.repo) - 1
+	if index != last {
+		m.repo[index] = m.repo[last]
+		m.uuidIndex[m.repo[index].UUID()] = index
+	}
+
+	m.repo = m.repo[:last]
+	return nil
+}
+
+func (m *Map) Dispose(uuid uuid.UUID, date time.Time, proceeds int64) (asset.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return asset.Entity{}, xerrors.ErrAssetNotFound
+	}
+
+	a := m.repo[index]
+
+	if err := a.Dispose(date, proceeds); err != nil {
+		return asset.Entity{}, err
+	}
+
+	m.repo[index] = a
+
+	var res asset.Entity
+	transform(&res, &a)
+	return res, nil
+}
+
+func some_then[T any](src opt.Opt[T], fn func(T) error) error {
+	if !src.Some {
+		return nil
+	}
+
+	return fn(src.Val)
+}
+
+func transform(r *asset.Entity, a *asset.Asset) {
+	r.UUID = a.UUID()
+	r.Book = a.Book()
+	r.Name = a.Name()
+	r.Cost = a.Cost()
+	r.Residual = a.Residual()
+	r.Life = a.Life()
+	r.Method = a.Method()
+	r.Factor = a.Factor()
+	r.Acquired = a.Acquired()
+
+	if disposal, ok := a.Disposal(); ok {
+		r.Disposal = opt.Some(disposal)
+	}
+}
+
+func clamp[T cmp.Ordered](mn, val, mx T) T {
+	return min(max(mn, val), mx)
+}
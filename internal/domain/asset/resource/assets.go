@@ -0,0 +1,299 @@
+package assets
+
+import (
+	"net/http"
+
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/domain/asset"
+	"github.com/alan-b-lima/prp/internal/domain/book"
+	"github.com/alan-b-lima/prp/internal/domain/session"
+	"github.com/alan-b-lima/prp/internal/domain/user"
+	"github.com/alan-b-lima/prp/internal/support"
+	"github.com/alan-b-lima/prp/pkg/errors"
+)
+
+const _SessionCookie = "session"
+
+type Resource struct {
+	http.ServeMux
+	Assets asset.Service
+	Users  user.Service
+}
+
+func New(assets asset.Repository, books book.Repository, users user.Repository, sessions session.Repository) *Resource {
+	rc := Resource{
+		Assets: *asset.NewService(assets, books),
+		Users:  *user.NewService(users, sessions),
+	}
+
+	routes := map[string]http.HandlerFunc{
+		"GET /books/{book}/assets/":                 rc.List,
+		"GET /books/{book}/assets/{uuid}":           rc.Get,
+		"POST /books/{book}/assets/":                rc.Create,
+		"PATCH /books/{book}/assets/{uuid}":         rc.Patch,
+		"DELETE /books/{book}/assets/{uuid}":        rc.Delete,
+		"GET /books/{book}/assets/{uuid}/schedule":  rc.Schedule,
+		"POST /books/{book}/assets/{uuid}/disposal": rc.Dispose,
+	}
+
+	for route, handler := range routes {
+		rc.Handle(route, handler)
+	}
+
+	return &rc
+}
+
+func (rc *Resource) List(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	req := asset.ListRequest{Book: book, Offset: 0, Limit: 10}
+
+	if err := support.LimitAndOffset(
+		query.Get("offset"), query.Get("limit"),
+		&req.Offset, &req.Limit,
+	); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Assets.List(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+	if res.Records == nil {
+		// avoid "null" encoding, once v2 rolls out,
+		// this can be removed
+		res.Records = []asset.Response{}
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := asset.GetRequest{Book: book, UUID: uuid}
+	res, err := rc.Assets.Get(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := asset.CreateRequest{Book: book}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Assets.Create(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusCreated, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Patch(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := asset.PatchRequest{Book: book, UUID: uuid}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Assets.Patch(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := asset.DeleteRequest{Book: book, UUID: uuid}
+	if err := rc.Assets.Delete(ctx, req); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *Resource) Schedule(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := asset.ScheduleRequest{Book: book, UUID: uuid}
+	res, err := rc.Assets.Schedule(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Dispose(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := asset.DisposeRequest{Book: book, UUID: uuid}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Assets.Dispose(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusCreated, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) session(w http.ResponseWriter, r *http.Request) (auth.Context, error) {
+	session, err := support.SessionCookie(_SessionCookie, w, r)
+	if err != nil {
+		return auth.NewUnlogged(), nil
+	}
+
+	ctx, err := rc.Users.Context(user.ContextRequest{Session: session})
+	if err, ok := errors.AsType[*errors.Error](err); ok && err.Kind.IsClient() {
+		return auth.NewUnlogged(), nil
+	}
+	if err != nil {
+		return auth.NewUnlogged(), err
+	}
+
+	return ctx, err
+}
@@ -0,0 +1,98 @@
+package asset
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type (
+	ListRequest struct {
+		Book   uuid.UUID `json:"-"`
+		Offset int       `json:"-"`
+		Limit  int       `json:"-"`
+	}
+
+	GetRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	CreateRequest struct {
+		Book     uuid.UUID `json:"-"`
+		Name     string    `json:"name"`
+		Cost     int64     `json:"cost"`
+		Residual int64     `json:"residual"`
+		Life     int       `json:"life"`
+		Method   string    `json:"method"`
+		Factor   float64   `json:"factor"`
+		Acquired time.Time `json:"acquired"`
+	}
+
+	PatchRequest struct {
+		Book uuid.UUID       `json:"-"`
+		UUID uuid.UUID       `json:"-"`
+		Name opt.Opt[string] `json:"name"`
+	}
+
+	DeleteRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	ScheduleRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	DisposeRequest struct {
+		Book     uuid.UUID `json:"-"`
+		UUID     uuid.UUID `json:"-"`
+		Date     time.Time `json:"date"`
+		Proceeds int64     `json:"proceeds"`
+	}
+)
+
+type (
+	ListResponse struct {
+		Offset       int        `json:"offset"`
+		Length       int        `json:"length"`
+		Records      []Response `json:"records"`
+		TotalRecords int        `json:"total_records"`
+	}
+
+	Response struct {
+		UUID     uuid.UUID                 `json:"uuid"`
+		Book     uuid.UUID                 `json:"book"`
+		Name     string                    `json:"name"`
+		Cost     int64                     `json:"cost"`
+		Residual int64                     `json:"residual"`
+		Life     int                       `json:"life"`
+		Method   string                    `json:"method"`
+		Factor   float64                   `json:"factor,omitempty"`
+		Acquired time.Time                 `json:"acquired"`
+		Disposal opt.Opt[DisposalResponse] `json:"disposal"`
+	}
+
+	DisposalResponse struct {
+		Date      time.Time `json:"date"`
+		Proceeds  int64     `json:"proceeds"`
+		BookValue int64     `json:"book_value"`
+		Gain      int64     `json:"gain"`
+	}
+
+	ScheduleResponse struct {
+		Asset             uuid.UUID        `json:"asset"`
+		Periods           []PeriodResponse `json:"periods"`
+		TotalDepreciation int64            `json:"total_depreciation"`
+	}
+
+	PeriodResponse struct {
+		Period       int       `json:"period"`
+		Date         time.Time `json:"date"`
+		Depreciation int64     `json:"depreciation"`
+		Accumulated  int64     `json:"accumulated"`
+		BookValue    int64     `json:"book_value"`
+	}
+)
@@ -0,0 +1,215 @@
+package book
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/domain/user"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func List(books Lister, req ListRequest) (ListResponse, error) {
+	res, err := books.List(req.Member, req.Offset, req.Limit)
+	if err != nil {
+		return ListResponse{}, err
+	}
+
+	ares := ListResponse{
+		Offset:       res.Offset,
+		Length:       res.Length,
+		Records:      make([]Response, res.Length),
+		TotalRecords: res.TotalRecords,
+	}
+	for i := 0; i < res.Length; i++ {
+		transform(&ares.Records[i], &res.Records[i])
+	}
+
+	return ares, nil
+}
+
+func Get(books Getter, req GetRequest) (Response, error) {
+	res, err := books.Get(req.UUID)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Create(books Creater, req CreateRequest) (Response, error) {
+	res, err := books.Create(req.Name, req.Owner)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Patch(books Patcher, req PatchRequest) (Response, error) {
+	res, err := books.Patch(req.UUID, req.Name)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+// Delete deletes a book, as long as none of the contents, counted by
+// name, has anything left in it. The book is locked from the count to
+// the deletion, so no content is created in between.
+func Delete(books Remover, contents map[string]Counter, req DeleteRequest) error {
+	defer books.LockContents(req.UUID)()
+
+	var left []string
+	for name, counter := range contents {
+		n, err := counter.Count(req.UUID)
+		if err != nil {
+			return err
+		}
+
+		if n > 0 {
+			left = append(left, name)
+		}
+	}
+
+	if len(left) > 0 {
+		slices.Sort(left)
+		return xerrors.ErrBookNotEmpty.New(strings.Join(left, ", "))
+	}
+
+	return books.Delete(req.UUID)
+}
+
+func SetMember(books MemberSetter, req SetMemberRequest) (Response, error) {
+	res, err := books.SetMember(req.UUID, req.User, req.Role)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func RemoveMember(books MemberRemover, req RemoveMemberRequest) error {
+	return books.RemoveMember(req.UUID, req.User)
+}
+
+func Invite(books Inviter, users user.GetterByLogin, req InviteRequest) (InvitationResponse, error) {
+	u, err := users.GetByLogin(req.Login)
+	if err != nil {
+		return InvitationResponse{}, err
+	}
+
+	res, err := books.Invite(req.UUID, u.UUID, req.Role, req.Inviter)
+	if err != nil {
+		return InvitationResponse{}, err
+	}
+
+	var ares InvitationResponse
+	transformInvitation(&ares, &res)
+	return ares, nil
+}
+
+func Invitations(books InvitationLister, req InvitationsRequest) (InvitationsResponse, error) {
+	res, err := books.Invitations(req.User)
+	if err != nil {
+		return InvitationsResponse{}, err
+	}
+
+	ares := InvitationsResponse{Records: make([]InvitationResponse, len(res))}
+	for i := range res {
+		transformInvitation(&ares.Records[i], &res[i])
+	}
+
+	return ares, nil
+}
+
+func Accept(books InvitationAccepter, req AcceptRequest) (Response, error) {
+	res, err := books.Accept(req.Invitation)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Decline(books InvitationDecliner, req DeclineRequest) error {
+	return books.Decline(req.Invitation)
+}
+
+// Authorize checks whether the logged user is a member of the book
+// with at least the given role. It's meant to be used by the services
+// of every resource scoped to a book.
+func Authorize(books MemberGetter, ctx auth.Context, book uuid.UUID, role Role) error {
+	if l := ctx.Level(); !l.IsValid() {
+		return xerrors.ErrUnauthenticatedUser
+	}
+
+	r, err := books.Member(book, ctx.User())
+	if err != nil {
+		return err
+	}
+
+	if !r.Allows(role) {
+		return xerrors.ErrBookRoleTooLow.New(r, role)
+	}
+
+	return nil
+}
+
+// Owned is a resource scoped to a book.
+type Owned interface {
+	InBook() uuid.UUID
+}
+
+// AuthorizeOwned checks, as [Authorize], the membership of the logged
+// user in the book, and whether the item, got with get, belongs to it.
+// Items of other books are reported with notFound, so that they cannot
+// be told apart from those that do not exist.
+func AuthorizeOwned[E Owned](books MemberGetter, ctx auth.Context, book uuid.UUID, role Role, get func(uuid.UUID) (E, error), item uuid.UUID, notFound error) error {
+	if err := Authorize(books, ctx, book, role); err != nil {
+		return err
+	}
+
+	res, err := get(item)
+	if err != nil {
+		return err
+	}
+
+	if res.InBook() != book {
+		return notFound
+	}
+
+	return nil
+}
+
+func transform(r *Response, e *Entity) {
+	r.UUID = e.UUID
+	r.Name = e.Name
+
+	r.Members = make([]MemberResponse, len(e.Members))
+	for i, m := range e.Members {
+		r.Members[i] = MemberResponse{User: m.User, Role: m.Role.String()}
+	}
+}
+
+func transformInvitation(r *InvitationResponse, e *InvitationEntity) {
+	r.UUID = e.UUID
+	r.Book = e.Book
+	r.BookName = e.BookName
+	r.User = e.User
+	r.Role = e.Role.String()
+	r.Inviter = e.Inviter
+	r.Created = e.Created
+}
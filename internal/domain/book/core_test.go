@@ -0,0 +1,137 @@
+package book_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/auth"
+	. "github.com/alan-b-lima/prp/internal/domain/book"
+	bookrepo "github.com/alan-b-lima/prp/internal/domain/book/repository"
+	"github.com/alan-b-lima/prp/internal/domain/invoice"
+	invoicerepo "github.com/alan-b-lima/prp/internal/domain/invoice/repository"
+	payeerepo "github.com/alan-b-lima/prp/internal/domain/payee/repository"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func TestDeleteNotEmpty(t *testing.T) {
+	books := bookrepo.NewMap()
+	payees := payeerepo.NewMap()
+	invoices := invoicerepo.NewMap()
+	contents := map[string]Counter{"payees": payees, "invoices": invoices}
+
+	b, err := books.Create("home", uuid.NewUUIDv7())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := payees.Create(b.UUID, "market", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	inv, err := invoices.Create(b.UUID, "ACME", "", now, now, []invoice.Item{{Description: "work", Quantity: invoice.Unit}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Delete(books, contents, DeleteRequest{UUID: b.UUID})
+	if err, ok := errors.AsType[*errors.Error](err); !ok || err.Title != "book-not-empty" || err.Kind != errors.Conflict {
+		t.Fatalf("expected a book-not-empty conflict, got %v", err)
+	}
+
+	if _, err := books.Get(b.UUID); err != nil {
+		t.Fatalf("book should not have been deleted, got %v", err)
+	}
+
+	if err := payees.Delete(p.UUID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := invoices.Delete(inv.UUID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Delete(books, contents, DeleteRequest{UUID: b.UUID}); err != nil {
+		t.Fatalf("empty book should be deleted, got %v", err)
+	}
+
+	if _, err := books.Get(b.UUID); err == nil {
+		t.Error("book should have been deleted")
+	}
+}
+
+func TestDeleteWaitsForCreations(t *testing.T) {
+	books := bookrepo.NewMap()
+	payees := payeerepo.NewMap()
+	contents := map[string]Counter{"payees": payees}
+
+	b, err := books.Create("home", uuid.NewUUIDv7())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a creation has checked the membership and is yet to create
+	unlock := books.RLockContents(b.UUID)
+
+	done := make(chan error)
+	go func() { done <- Delete(books, contents, DeleteRequest{UUID: b.UUID}) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("deletion should wait for the creation, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if _, err := payees.Create(b.UUID, "market", nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+
+	err = <-done
+	if err, ok := errors.AsType[*errors.Error](err); !ok || err.Title != "book-not-empty" {
+		t.Fatalf("expected a book-not-empty conflict, got %v", err)
+	}
+}
+
+func TestAuthorizeOwned(t *testing.T) {
+	books := bookrepo.NewMap()
+	payees := payeerepo.NewMap()
+
+	owner := uuid.NewUUIDv7()
+	ctx := auth.NewLogged(owner, auth.User)
+
+	mine, err := books.Create("home", owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := books.Create("office", uuid.NewUUIDv7())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := payees.Create(mine.UUID, "market", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := payees.Create(other.UUID, "bakery", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AuthorizeOwned(books, ctx, mine.UUID, Editor, payees.Get, p.UUID, xerrors.ErrPayeeNotFound); err != nil {
+		t.Errorf("payee of the book should be authorized, got %v", err)
+	}
+
+	if err := AuthorizeOwned(books, ctx, mine.UUID, Editor, payees.Get, q.UUID, xerrors.ErrPayeeNotFound); err != xerrors.ErrPayeeNotFound {
+		t.Errorf("payee of another book should not be found, got %v", err)
+	}
+
+	if err := AuthorizeOwned(books, ctx, other.UUID, Viewer, payees.Get, q.UUID, xerrors.ErrPayeeNotFound); err == nil {
+		t.Error("non-members should not be authorized")
+	}
+}
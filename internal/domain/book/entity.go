@@ -0,0 +1,202 @@
+package book
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Book struct {
+	uuid        uuid.UUID
+	name        string
+	members     []Member
+	invitations []Invitation
+}
+
+type Member struct {
+	User uuid.UUID
+	Role Role
+}
+
+type Invitation struct {
+	UUID    uuid.UUID
+	User    uuid.UUID
+	Role    Role
+	Inviter uuid.UUID
+	Created time.Time
+}
+
+func New(name string, owner uuid.UUID) (Book, error) {
+	var b Book
+
+	err := errors.Join(
+		b.SetName(name),
+	)
+	if err != nil {
+		return Book{}, xerrors.ErrBookCreation.New(err)
+	}
+
+	b.uuid = uuid.NewUUIDv7()
+	b.members = []Member{{User: owner, Role: Owner}}
+	return b, nil
+}
+
+func (b *Book) UUID() uuid.UUID           { return b.uuid }
+func (b *Book) Name() string              { return b.name }
+func (b *Book) Members() []Member         { return slices.Clone(b.members) }
+func (b *Book) Invitations() []Invitation { return slices.Clone(b.invitations) }
+
+func (b *Book) SetName(name string) error { return set(&b.name, name, ProcessName) }
+
+// Role returns the role of the user in the book, ok is false if the
+// user is not a member.
+func (b *Book) Role(user uuid.UUID) (role Role, ok bool) {
+	i := b.member(user)
+	if i < 0 {
+		return 0, false
+	}
+
+	return b.members[i].Role, true
+}
+
+// SetRole changes the role of a member, a book must always have at
+// least one owner.
+func (b *Book) SetRole(user uuid.UUID, role string) error {
+	r, err := ProcessRole(role)
+	if err != nil {
+		return err
+	}
+
+	i := b.member(user)
+	if i < 0 {
+		return xerrors.ErrNotBookMember
+	}
+
+	if b.members[i].Role == Owner && r != Owner && b.owners() == 1 {
+		return xerrors.ErrBookLastOwner
+	}
+
+	b.members[i].Role = r
+	return nil
+}
+
+// RemoveMember removes a member from the book, a book must always
+// have at least one owner.
+func (b *Book) RemoveMember(user uuid.UUID) error {
+	i := b.member(user)
+	if i < 0 {
+		return xerrors.ErrNotBookMember
+	}
+
+	if b.members[i].Role == Owner && b.owners() == 1 {
+		return xerrors.ErrBookLastOwner
+	}
+
+	b.members = slices.Delete(b.members, i, i+1)
+	return nil
+}
+
+// Invite creates an invitation for the user to join the book with
+// the given role, a user may only have one pending invitation per
+// book.
+func (b *Book) Invite(user uuid.UUID, role string, inviter uuid.UUID) (Invitation, error) {
+	r, err := ProcessRole(role)
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	if b.member(user) >= 0 {
+		return Invitation{}, xerrors.ErrAlreadyBookMember
+	}
+
+	if slices.ContainsFunc(b.invitations, func(i Invitation) bool { return i.User == user }) {
+		return Invitation{}, xerrors.ErrAlreadyInvited
+	}
+
+	inv := Invitation{
+		UUID:    uuid.NewUUIDv7(),
+		User:    user,
+		Role:    r,
+		Inviter: inviter,
+		Created: time.Now(),
+	}
+
+	b.invitations = append(b.invitations, inv)
+	return inv, nil
+}
+
+// Accept turns the invitation into a membership.
+func (b *Book) Accept(invitation uuid.UUID) error {
+	i := b.invitation(invitation)
+	if i < 0 {
+		return xerrors.ErrInvitationNotFound
+	}
+
+	inv := b.invitations[i]
+	b.invitations = slices.Delete(b.invitations, i, i+1)
+	b.members = append(b.members, Member{User: inv.User, Role: inv.Role})
+	return nil
+}
+
+// Decline discards the invitation, whether declined by the invitee or
+// revoked by an owner.
+func (b *Book) Decline(invitation uuid.UUID) error {
+	i := b.invitation(invitation)
+	if i < 0 {
+		return xerrors.ErrInvitationNotFound
+	}
+
+	b.invitations = slices.Delete(b.invitations, i, i+1)
+	return nil
+}
+
+func (b *Book) member(user uuid.UUID) int {
+	return slices.IndexFunc(b.members, func(m Member) bool { return m.User == user })
+}
+
+func (b *Book) invitation(invitation uuid.UUID) int {
+	return slices.IndexFunc(b.invitations, func(i Invitation) bool { return i.UUID == invitation })
+}
+
+func (b *Book) owners() int {
+	var n int
+	for _, m := range b.members {
+		if m.Role == Owner {
+			n++
+		}
+	}
+
+	return n
+}
+
+func ProcessName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", xerrors.ErrNameEmpty
+	}
+
+	return name, nil
+}
+
+func ProcessRole(role string) (Role, error) {
+	r, ok := stringRoles[role]
+	if !ok {
+		return 0, xerrors.ErrBadRole
+	}
+
+	return r, nil
+}
+
+func set[T, R any](dst *R, src T, fn func(T) (R, error)) error {
+	val, err := fn(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = val
+	return nil
+}
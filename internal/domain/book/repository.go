@@ -0,0 +1,128 @@
+package book
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Repository interface {
+	Lister
+	Getter
+	Creater
+	Patcher
+	Deleter
+	MemberGetter
+	ContentLocker
+	MemberSetter
+	MemberRemover
+	Inviter
+	InvitationLister
+	InvitationGetter
+	InvitationAccepter
+	InvitationDecliner
+}
+
+type Lister interface {
+	List(member uuid.UUID, offset, limit int) (ListEntity, error)
+}
+
+type Getter interface {
+	Get(uuid uuid.UUID) (Entity, error)
+}
+
+type Creater interface {
+	Create(name string, owner uuid.UUID) (Entity, error)
+}
+
+type Patcher interface {
+	Patch(uuid uuid.UUID, name opt.Opt[string]) (Entity, error)
+}
+
+type Deleter interface {
+	Delete(uuid uuid.UUID) error
+}
+
+// Counter counts the resources of some kind in a book, books can
+// only be deleted once nothing is left in them.
+type Counter interface {
+	Count(book uuid.UUID) (int, error)
+}
+
+type MemberGetter interface {
+	Member(book, user uuid.UUID) (Role, error)
+}
+
+// ContentLocker locks a book against the creation of contents, so
+// that it is not deleted while they are created. Creations read lock
+// the book, from the membership check to the creation itself, while
+// deletion write locks it, from the count of the contents to the
+// deletion. Both return the function that unlocks the book.
+type ContentLocker interface {
+	LockContents(book uuid.UUID) (unlock func())
+	RLockContents(book uuid.UUID) (unlock func())
+}
+
+// ContentGuard is what the resources scoped to a book need from it.
+type ContentGuard interface {
+	MemberGetter
+	ContentLocker
+}
+
+// Remover deletes books once they are empty.
+type Remover interface {
+	Deleter
+	ContentLocker
+}
+
+type MemberSetter interface {
+	SetMember(book, user uuid.UUID, role string) (Entity, error)
+}
+
+type MemberRemover interface {
+	RemoveMember(book, user uuid.UUID) error
+}
+
+type Inviter interface {
+	Invite(book, user uuid.UUID, role string, inviter uuid.UUID) (InvitationEntity, error)
+}
+
+type InvitationLister interface {
+	Invitations(user uuid.UUID) ([]InvitationEntity, error)
+}
+
+type InvitationGetter interface {
+	Invitation(uuid uuid.UUID) (InvitationEntity, error)
+}
+
+type InvitationAccepter interface {
+	Accept(invitation uuid.UUID) (Entity, error)
+}
+
+type InvitationDecliner interface {
+	Decline(invitation uuid.UUID) error
+}
+
+type Entity struct {
+	UUID    uuid.UUID
+	Name    string
+	Members []Member
+}
+
+type InvitationEntity struct {
+	UUID     uuid.UUID
+	Book     uuid.UUID
+	BookName string
+	User     uuid.UUID
+	Role     Role
+	Inviter  uuid.UUID
+	Created  time.Time
+}
+
+type ListEntity struct {
+	Offset       int
+	Length       int
+	Records      []Entity
+	TotalRecords int
+}
@@ -0,0 +1,348 @@
+package bookrepo
+
+import (
+	"cmp"
+	"sync"
+
+	"github.com/alan-b-lima/prp/internal/domain/book"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Map struct {
+	uuidIndex       map[uuid.UUID]int
+	invitationIndex map[uuid.UUID]uuid.UUID
+
+	repo []book.Book
+	mu   sync.RWMutex
+
+	// locks of the contents of each book, apart from mu, which is
+	// taken by the membership checks made while holding them
+	contents   map[uuid.UUID]*sync.RWMutex
+	contentsMu sync.Mutex
+}
+
+func NewMap() book.Repository {
+	repo := Map{
+		uuidIndex:       make(map[uuid.UUID]int),
+		invitationIndex: make(map[uuid.UUID]uuid.UUID),
+		contents:        make(map[uuid.UUID]*sync.RWMutex),
+	}
+
+	return &repo
+}
+
+func (m *Map) List(member uuid.UUID, offset, limit int) (book.ListEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var joined []*book.Book
+	for i := range m.repo {
+		if _, ok := m.repo[i].Role(member); ok {
+			joined = append(joined, &m.repo[i])
+		}
+	}
+
+	lo := clamp(0, offset, len(joined))
+	hi := clamp(0, offset+limit, len(joined))
+
+	if lo >= hi {
+		return book.ListEntity{TotalRecords: len(joined)}, nil
+	}
+
+	res := make([]book.Entity, hi-lo)
+	for i, b := range joined[lo:hi] {
+		transform(&res[i], b)
+	}
+
+	return book.ListEntity{
+		Offset:       lo,
+		Length:       len(res),
+		Records:      res,
+		TotalRecords: len(joined),
+	}, nil
+}
+
+func (m *Map) Get(uuid uuid.UUID) (book.Entity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return book.Entity{}, xerrors.ErrBookNotFound
+	}
+
+	var res book.Entity
+	transform(&res, &m.repo[index])
+	return res, nil
+}
+
+func (m *Map) Create(name string, owner uuid.UUID) (book.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	b, err := book.New(name, owner)
+	if err != nil {
+		return book.Entity{}, err
+	}
+
+	m.uuidIndex[b.UUID()] = len(m.repo)
+	m.repo = append(m.repo, b)
+
+	var res book.Entity
+	transform(&res, &b)
+	return res, nil
+}
+
+func (m *Map) Patch(uuid uuid.UUID, name opt.Opt[string]) (book.Entity, error) {
+	return m.update(uuid, func(b *book.Book) error {
+		return some_then(name, b.SetName)
+	})
+}
+
+func (m *Map) Delete(uuid uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return nil
+	}
+
+	for _, inv := range m.repo[index].Invitations() {
+		delete(m.invitationIndex, inv.UUID)
+	}
+
+	delete(m.uuidIndex, uuid)
+
+	m.contentsMu.Lock()
+	delete(m.contents, uuid)
+	m.contentsMu.Unlock()
+
+	last := len(m.repo) - 1
+	if index != last {
+		m.repo[index] = m.repo[last]
+		m.uuidIndex[m.repo[index].UUID()] = index
+	}
+
+	m.repo = m.repo[:last]
+	return nil
+}
+
+func (m *Map) LockContents(book uuid.UUID) func() {
+	mu := m.contentsLock(book)
+	mu.Lock()
+	return mu.Unlock
+}
+
+func (m *Map) RLockContents(book uuid.UUID) func() {
+	mu := m.contentsLock(book)
+	mu.RLock()
+	return mu.RUnlock
+}
+
+// contentsLock returns the lock of the contents of the book, books
+// that do not exist get a lock of their own, there is nothing to
+// protect in them and membership checks fail anyway.
+func (m *Map) contentsLock(book uuid.UUID) *sync.RWMutex {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	if _, in := m.uuidIndex[book]; !in {
+		return new(sync.RWMutex)
+	}
+
+	defer m.contentsMu.Unlock()
+	m.contentsMu.Lock()
+
+	mu, in := m.contents[book]
+	if !in {
+		mu = new(sync.RWMutex)
+		m.contents[book] = mu
+	}
+
+	return mu
+}
+
+func (m *Map) Member(uuid, user uuid.UUID) (book.Role, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return 0, xerrors.ErrBookNotFound
+	}
+
+	role, ok := m.repo[index].Role(user)
+	if !ok {
+		return 0, xerrors.ErrNotBookMember
+	}
+
+	return role, nil
+}
+
+func (m *Map) SetMember(uuid, user uuid.UUID, role string) (book.Entity, error) {
+	return m.update(uuid, func(b *book.Book) error {
+		return b.SetRole(user, role)
+	})
+}
+
+func (m *Map) RemoveMember(uuid, user uuid.UUID) error {
+	_, err := m.update(uuid, func(b *book.Book) error {
+		return b.RemoveMember(user)
+	})
+
+	return err
+}
+
+func (m *Map) Invite(uuid, user uuid.UUID, role string, inviter uuid.UUID) (book.InvitationEntity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return book.InvitationEntity{}, xerrors.ErrBookNotFound
+	}
+
+	b := &m.repo[index]
+
+	inv, err := b.Invite(user, role, inviter)
+	if err != nil {
+		return book.InvitationEntity{}, err
+	}
+
+	m.invitationIndex[inv.UUID] = b.UUID()
+
+	var res book.InvitationEntity
+	transformInvitation(&res, b, &inv)
+	return res, nil
+}
+
+func (m *Map) Invitations(user uuid.UUID) ([]book.InvitationEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var res []book.InvitationEntity
+	for i := range m.repo {
+		b := &m.repo[i]
+
+		for _, inv := range b.Invitations() {
+			if inv.User != user {
+				continue
+			}
+
+			var r book.InvitationEntity
+			transformInvitation(&r, b, &inv)
+			res = append(res, r)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *Map) Invitation(uuid uuid.UUID) (book.InvitationEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	b, in := m.invitationIndex[uuid]
+	if !in {
+		return book.InvitationEntity{}, xerrors.ErrInvitationNotFound
+	}
+
+	bk := &m.repo[m.uuidIndex[b]]
+	for _, inv := range bk.Invitations() {
+		if inv.UUID == uuid {
+			var res book.InvitationEntity
+			transformInvitation(&res, bk, &inv)
+			return res, nil
+		}
+	}
+
+	return book.InvitationEntity{}, xerrors.ErrInvitationNotFound
+}
+
+func (m *Map) Accept(invitation uuid.UUID) (book.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	b, in := m.invitationIndex[invitation]
+	if !in {
+		return book.Entity{}, xerrors.ErrInvitationNotFound
+	}
+
+	bk := &m.repo[m.uuidIndex[b]]
+	if err := bk.Accept(invitation); err != nil {
+		return book.Entity{}, err
+	}
+
+	delete(m.invitationIndex, invitation)
+
+	var res book.Entity
+	transform(&res, bk)
+	return res, nil
+}
+
+func (m *Map) Decline(invitation uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	b, in := m.invitationIndex[invitation]
+	if !in {
+		return xerrors.ErrInvitationNotFound
+	}
+
+	if err := m.repo[m.uuidIndex[b]].Decline(invitation); err != nil {
+		return err
+	}
+
+	delete(m.invitationIndex, invitation)
+	return nil
+}
+
+func (m *Map) update(uuid uuid.UUID, fn func(*book.Book) error) (book.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return book.Entity{}, xerrors.ErrBookNotFound
+	}
+
+	b := &m.repo[index]
+	if err := fn(b); err != nil {
+		return book.Entity{}, err
+	}
+
+	var res book.Entity
+	transform(&res, b)
+	return res, nil
+}
+
+func some_then[T any](src opt.Opt[T], fn func(T) error) error {
+	if !src.Some {
+		return nil
+	}
+
+	return fn(src.Val)
+}
+
+func transform(r *book.Entity, b *book.Book) {
+	r.UUID = b.UUID()
+	r.Name = b.Name()
+	r.Members = b.Members()
+}
+
+func transformInvitation(r *book.InvitationEntity, b *book.Book, i *book.Invitation) {
+	r.UUID = i.UUID
+	r.Book = b.UUID()
+	r.BookName = b.Name()
+	r.User = i.User
+	r.Role = i.Role
+	r.Inviter = i.Inviter
+	r.Created = i.Created
+}
+
+func clamp[T cmp.Ordered](mn, val, mx T) T {
+	return min(max(mn, val), mx)
+}
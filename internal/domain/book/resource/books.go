@@ -0,0 +1,371 @@
+package books
+
+import (
+	"net/http"
+
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/domain/book"
+	"github.com/alan-b-lima/prp/internal/domain/session"
+	"github.com/alan-b-lima/prp/internal/domain/user"
+	"github.com/alan-b-lima/prp/internal/support"
+	"github.com/alan-b-lima/prp/pkg/errors"
+)
+
+const _SessionCookie = "session"
+
+type Resource struct {
+	http.ServeMux
+	Books book.Service
+	Users user.Service
+}
+
+func New(books book.Repository, contents map[string]book.Counter, users user.Repository, sessions session.Repository) *Resource {
+	rc := Resource{
+		Books: *book.NewService(books, users, contents),
+		Users: *user.NewService(users, sessions),
+	}
+
+	routes := map[string]http.HandlerFunc{
+		"GET /books/":                         rc.List,
+		"GET /books/{uuid}":                   rc.Get,
+		"POST /books/":                        rc.Create,
+		"PATCH /books/{uuid}":                 rc.Patch,
+		"DELETE /books/{uuid}":                rc.Delete,
+		"PATCH /books/{uuid}/members/{user}":  rc.SetMember,
+		"DELETE /books/{uuid}/members/{user}": rc.RemoveMember,
+		"POST /books/{uuid}/invitations/":     rc.Invite,
+		"GET /books/invitations/":             rc.Invitations,
+		"POST /books/invitations/{uuid}":      rc.Accept,
+		"DELETE /books/invitations/{uuid}":    rc.Decline,
+	}
+
+	for route, handler := range routes {
+		rc.Handle(route, handler)
+	}
+
+	return &rc
+}
+
+func (rc *Resource) List(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	req := book.ListRequest{Offset: 0, Limit: 10}
+
+	if err := support.LimitAndOffset(
+		query.Get("offset"), query.Get("limit"),
+		&req.Offset, &req.Limit,
+	); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Books.List(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+	if res.Records == nil {
+		// avoid "null" encoding, once v2 rolls out,
+		// this can be removed
+		res.Records = []book.Response{}
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := book.GetRequest{UUID: uuid}
+	res, err := rc.Books.Get(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	var req book.CreateRequest
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Books.Create(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusCreated, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Patch(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := book.PatchRequest{UUID: uuid}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Books.Patch(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := book.DeleteRequest{UUID: uuid}
+	if err := rc.Books.Delete(ctx, req); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *Resource) SetMember(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	member, err := support.UUIDFromString(r.PathValue("user"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := book.SetMemberRequest{UUID: uuid, User: member}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Books.SetMember(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	member, err := support.UUIDFromString(r.PathValue("user"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := book.RemoveMemberRequest{UUID: uuid, User: member}
+	if err := rc.Books.RemoveMember(ctx, req); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *Resource) Invite(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := book.InviteRequest{UUID: uuid}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Books.Invite(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusCreated, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Invitations(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Books.Invitations(ctx, book.InvitationsRequest{})
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+	if res.Records == nil {
+		// avoid "null" encoding, once v2 rolls out,
+		// this can be removed
+		res.Records = []book.InvitationResponse{}
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Accept(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := book.AcceptRequest{Invitation: uuid}
+	res, err := rc.Books.Accept(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Decline(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := book.DeclineRequest{Invitation: uuid}
+	if err := rc.Books.Decline(ctx, req); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *Resource) session(w http.ResponseWriter, r *http.Request) (auth.Context, error) {
+	session, err := support.SessionCookie(_SessionCookie, w, r)
+	if err != nil {
+		return auth.NewUnlogged(), nil
+	}
+
+	ctx, err := rc.Users.Context(user.ContextRequest{Session: session})
+	if err, ok := errors.AsType[*errors.Error](err); ok && err.Kind.IsClient() {
+		return auth.NewUnlogged(), nil
+	}
+	if err != nil {
+		return auth.NewUnlogged(), err
+	}
+
+	return ctx, err
+}
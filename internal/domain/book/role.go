@@ -0,0 +1,33 @@
+package book
+
+type Role int
+
+const (
+	_ Role = iota
+
+	Viewer
+	Editor
+	Owner
+)
+
+var roleStrings = map[Role]string{
+	Viewer: "viewer",
+	Editor: "editor",
+	Owner:  "owner",
+}
+
+var stringRoles = map[string]Role{
+	"viewer": Viewer,
+	"editor": Editor,
+	"owner":  Owner,
+}
+
+// Allows reports whether the role grants what is granted to the
+// given one, roles are ordered as viewer < editor < owner.
+func (r Role) Allows(role Role) bool {
+	return r >= role
+}
+
+func (r Role) String() string {
+	return roleStrings[r]
+}
@@ -0,0 +1,151 @@
+package book
+
+import (
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/domain/user"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+)
+
+type Service struct {
+	Repo     Repository
+	Users    user.Repository
+	Contents map[string]Counter
+}
+
+func NewService(books Repository, users user.Repository, contents map[string]Counter) *Service {
+	return &Service{
+		Repo:     books,
+		Users:    users,
+		Contents: contents,
+	}
+}
+
+var PermGeneral = auth.Permission(auth.Admin, auth.User)
+
+func (s *Service) List(ctx auth.Context, req ListRequest) (ListResponse, error) {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return ListResponse{}, xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	req.Member = ctx.User()
+	return List(s.Repo, req)
+}
+
+func (s *Service) Get(ctx auth.Context, req GetRequest) (Response, error) {
+	if err := Authorize(s.Repo, ctx, req.UUID, Viewer); err != nil {
+		return Response{}, err
+	}
+
+	return Get(s.Repo, req)
+}
+
+func (s *Service) Create(ctx auth.Context, req CreateRequest) (Response, error) {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return Response{}, xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	req.Owner = ctx.User()
+	return Create(s.Repo, req)
+}
+
+func (s *Service) Patch(ctx auth.Context, req PatchRequest) (Response, error) {
+	if err := Authorize(s.Repo, ctx, req.UUID, Owner); err != nil {
+		return Response{}, err
+	}
+
+	return Patch(s.Repo, req)
+}
+
+func (s *Service) Delete(ctx auth.Context, req DeleteRequest) error {
+	if err := Authorize(s.Repo, ctx, req.UUID, Owner); err != nil {
+		return err
+	}
+
+	return Delete(s.Repo, s.Contents, req)
+}
+
+func (s *Service) SetMember(ctx auth.Context, req SetMemberRequest) (Response, error) {
+	if err := Authorize(s.Repo, ctx, req.UUID, Owner); err != nil {
+		return Response{}, err
+	}
+
+	return SetMember(s.Repo, req)
+}
+
+func (s *Service) RemoveMember(ctx auth.Context, req RemoveMemberRequest) error {
+	// any member may leave a book
+	if ctx.User() == req.User {
+		if err := Authorize(s.Repo, ctx, req.UUID, Viewer); err != nil {
+			return err
+		}
+
+		goto Do
+	}
+
+	if err := Authorize(s.Repo, ctx, req.UUID, Owner); err != nil {
+		return err
+	}
+
+Do:
+	return RemoveMember(s.Repo, req)
+}
+
+func (s *Service) Invite(ctx auth.Context, req InviteRequest) (InvitationResponse, error) {
+	if err := Authorize(s.Repo, ctx, req.UUID, Owner); err != nil {
+		return InvitationResponse{}, err
+	}
+
+	req.Inviter = ctx.User()
+	return Invite(s.Repo, s.Users, req)
+}
+
+func (s *Service) Invitations(ctx auth.Context, req InvitationsRequest) (InvitationsResponse, error) {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return InvitationsResponse{}, xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	req.User = ctx.User()
+	return Invitations(s.Repo, req)
+}
+
+func (s *Service) Accept(ctx auth.Context, req AcceptRequest) (Response, error) {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return Response{}, xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	inv, err := s.Repo.Invitation(req.Invitation)
+	if err != nil {
+		return Response{}, err
+	}
+
+	// only the invitee may accept, anyone else is told there is no
+	// such invitation
+	if inv.User != ctx.User() {
+		return Response{}, xerrors.ErrInvitationNotFound
+	}
+
+	return Accept(s.Repo, req)
+}
+
+func (s *Service) Decline(ctx auth.Context, req DeclineRequest) error {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	inv, err := s.Repo.Invitation(req.Invitation)
+	if err != nil {
+		return err
+	}
+
+	// the invitee declines, an owner revokes
+	if inv.User == ctx.User() {
+		goto Do
+	}
+
+	if err := Authorize(s.Repo, ctx, inv.Book, Owner); err != nil {
+		return xerrors.ErrInvitationNotFound
+	}
+
+Do:
+	return Decline(s.Repo, req)
+}
@@ -0,0 +1,98 @@
+package book
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type (
+	ListRequest struct {
+		Member uuid.UUID `json:"-"`
+		Offset int       `json:"-"`
+		Limit  int       `json:"-"`
+	}
+
+	GetRequest struct {
+		UUID uuid.UUID `json:"-"`
+	}
+
+	CreateRequest struct {
+		Owner uuid.UUID `json:"-"`
+		Name  string    `json:"name"`
+	}
+
+	PatchRequest struct {
+		UUID uuid.UUID       `json:"-"`
+		Name opt.Opt[string] `json:"name"`
+	}
+
+	DeleteRequest struct {
+		UUID uuid.UUID `json:"-"`
+	}
+
+	SetMemberRequest struct {
+		UUID uuid.UUID `json:"-"`
+		User uuid.UUID `json:"-"`
+		Role string    `json:"role"`
+	}
+
+	RemoveMemberRequest struct {
+		UUID uuid.UUID `json:"-"`
+		User uuid.UUID `json:"-"`
+	}
+
+	InviteRequest struct {
+		UUID    uuid.UUID `json:"-"`
+		Inviter uuid.UUID `json:"-"`
+		Login   string    `json:"login"`
+		Role    string    `json:"role"`
+	}
+
+	InvitationsRequest struct {
+		User uuid.UUID `json:"-"`
+	}
+
+	AcceptRequest struct {
+		Invitation uuid.UUID `json:"-"`
+	}
+
+	DeclineRequest struct {
+		Invitation uuid.UUID `json:"-"`
+	}
+)
+
+type (
+	ListResponse struct {
+		Offset       int        `json:"offset"`
+		Length       int        `json:"length"`
+		Records      []Response `json:"records"`
+		TotalRecords int        `json:"total_records"`
+	}
+
+	Response struct {
+		UUID    uuid.UUID        `json:"uuid"`
+		Name    string           `json:"name"`
+		Members []MemberResponse `json:"members"`
+	}
+
+	MemberResponse struct {
+		User uuid.UUID `json:"user"`
+		Role string    `json:"role"`
+	}
+
+	InvitationsResponse struct {
+		Records []InvitationResponse `json:"records"`
+	}
+
+	InvitationResponse struct {
+		UUID     uuid.UUID `json:"uuid"`
+		Book     uuid.UUID `json:"book"`
+		BookName string    `json:"book_name"`
+		User     uuid.UUID `json:"user"`
+		Role     string    `json:"role"`
+		Inviter  uuid.UUID `json:"inviter"`
+		Created  time.Time `json:"created"`
+	}
+)
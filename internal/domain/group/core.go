@@ -0,0 +1,226 @@
+package group
+
+import (
+	"cmp"
+
+	"github.com/alan-b-lima/prp/internal/domain/user"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/split"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func List(groups Lister, req ListRequest) (ListResponse, error) {
+	res, err := groups.List(req.Member, req.Offset, req.Limit)
+	if err != nil {
+		return ListResponse{}, err
+	}
+
+	ares := ListResponse{
+		Offset:       res.Offset,
+		Length:       res.Length,
+		Records:      make([]Response, res.Length),
+		TotalRecords: res.TotalRecords,
+	}
+	for i := 0; i < res.Length; i++ {
+		transform(&ares.Records[i], &res.Records[i])
+	}
+
+	return ares, nil
+}
+
+func Get(groups Getter, req GetRequest) (Response, error) {
+	res, err := groups.Get(req.UUID)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Create(groups Creater, users user.GetterByLogin, req CreateRequest) (Response, error) {
+	members := make([]uuid.UUID, len(req.Members))
+	for i, login := range req.Members {
+		u, err := users.GetByLogin(login)
+		if err != nil {
+			return Response{}, err
+		}
+
+		members[i] = u.UUID
+	}
+
+	res, err := groups.Create(req.Name, req.Creator, members)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Delete(groups Deleter, req DeleteRequest) error {
+	return groups.Delete(req.UUID)
+}
+
+func AddMember(groups MemberAdder, users user.GetterByLogin, req AddMemberRequest) (Response, error) {
+	u, err := users.GetByLogin(req.Login)
+	if err != nil {
+		return Response{}, err
+	}
+
+	res, err := groups.AddMember(req.UUID, u.UUID)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func RemoveMember(groups MemberRemover, req RemoveMemberRequest) error {
+	return groups.RemoveMember(req.UUID, req.User)
+}
+
+func Expenses(groups Getter, req ExpensesRequest) (ExpensesResponse, error) {
+	res, err := groups.Get(req.UUID)
+	if err != nil {
+		return ExpensesResponse{}, err
+	}
+
+	lo := clamp(0, req.Offset, len(res.Expenses))
+	hi := clamp(0, req.Offset+req.Limit, len(res.Expenses))
+
+	if lo >= hi {
+		return ExpensesResponse{TotalRecords: len(res.Expenses)}, nil
+	}
+
+	ares := ExpensesResponse{
+		Offset:       lo,
+		Length:       hi - lo,
+		Records:      make([]ExpenseResponse, hi-lo),
+		TotalRecords: len(res.Expenses),
+	}
+	for i := range ares.Records {
+		transformExpense(&ares.Records[i], &res.Expenses[lo+i])
+	}
+
+	return ares, nil
+}
+
+func AddExpense(groups ExpenseAdder, req AddExpenseRequest) (ExpenseResponse, error) {
+	specs := make([]ShareSpec, len(req.Shares))
+	for i, s := range req.Shares {
+		specs[i] = ShareSpec{User: s.User, Percent: s.Percent, Amount: s.Amount}
+	}
+
+	res, err := groups.AddExpense(req.UUID, req.Description, req.Payer.Val, req.Amount, req.Date, req.Split, specs)
+	if err != nil {
+		return ExpenseResponse{}, err
+	}
+
+	var ares ExpenseResponse
+	transformExpense(&ares, &res)
+	return ares, nil
+}
+
+func DeleteExpense(groups ExpenseDeleter, req DeleteExpenseRequest) error {
+	return groups.DeleteExpense(req.UUID, req.Expense)
+}
+
+// Balances returns who owes and who is owed in the group, along with
+// the transfers that would settle every debt.
+func Balances(groups Getter, req BalancesRequest) (BalancesResponse, error) {
+	res, err := groups.Get(req.UUID)
+	if err != nil {
+		return BalancesResponse{}, err
+	}
+
+	balances := Net(res.Members, res.Expenses, res.Settlements)
+
+	nets := make([]int64, len(balances))
+	for i, b := range balances {
+		nets[i] = b.Net
+	}
+
+	ares := BalancesResponse{
+		Balances:  make([]BalanceResponse, len(balances)),
+		Transfers: []TransferResponse{},
+	}
+	for i, b := range balances {
+		ares.Balances[i] = BalanceResponse{User: b.User, Net: b.Net}
+	}
+
+	transfers, err := split.Settle(nets)
+	if err != nil {
+		return BalancesResponse{}, xerrors.ErrGroupUnbalanced
+	}
+
+	for _, t := range transfers {
+		ares.Transfers = append(ares.Transfers, TransferResponse{
+			From:   balances[t.From].User,
+			To:     balances[t.To].User,
+			Amount: t.Amount,
+		})
+	}
+
+	return ares, nil
+}
+
+func Settlements(groups Getter, req SettlementsRequest) (SettlementsResponse, error) {
+	res, err := groups.Get(req.UUID)
+	if err != nil {
+		return SettlementsResponse{}, err
+	}
+
+	ares := SettlementsResponse{Records: make([]SettlementResponse, len(res.Settlements))}
+	for i := range res.Settlements {
+		transformSettlement(&ares.Records[i], &res.Settlements[i])
+	}
+
+	return ares, nil
+}
+
+func Settle(groups Settler, req SettleRequest) (SettlementResponse, error) {
+	res, err := groups.Settle(req.UUID, req.From.Val, req.To, req.Amount, req.Date)
+	if err != nil {
+		return SettlementResponse{}, err
+	}
+
+	var ares SettlementResponse
+	transformSettlement(&ares, &res)
+	return ares, nil
+}
+
+func transform(r *Response, e *Entity) {
+	r.UUID = e.UUID
+	r.Name = e.Name
+	r.Members = e.Members
+}
+
+func transformExpense(r *ExpenseResponse, e *Expense) {
+	r.UUID = e.UUID
+	r.Description = e.Description
+	r.Payer = e.Payer
+	r.Amount = e.Amount
+	r.Date = e.Date
+
+	r.Shares = make([]ShareResponse, len(e.Shares))
+	for i, s := range e.Shares {
+		r.Shares[i] = ShareResponse{User: s.User, Amount: s.Amount}
+	}
+}
+
+func transformSettlement(r *SettlementResponse, s *Settlement) {
+	r.UUID = s.UUID
+	r.From = s.From
+	r.To = s.To
+	r.Amount = s.Amount
+	r.Date = s.Date
+}
+
+func clamp[T cmp.Ordered](mn, val, mx T) T {
+	return min(max(mn, val), mx)
+}
@@ -0,0 +1,41 @@
+package group_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/alan-b-lima/prp/internal/domain/group"
+	grouprepo "github.com/alan-b-lima/prp/internal/domain/group/repository"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func TestBalancesPercentSplit(t *testing.T) {
+	groups := grouprepo.NewMap()
+
+	alice, bob := uuid.NewUUIDv7(), uuid.NewUUIDv7()
+	g, err := groups.Create("trip", alice, []uuid.UUID{bob})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// percentages a hair over 100 are accepted, the shares must still
+	// add up to the amount
+	specs := []ShareSpec{{User: alice, Percent: 50.0000005}, {User: bob, Percent: 50.0000005}}
+	if _, err := groups.AddExpense(g.UUID, "flat", alice, 1e12, time.Now(), "percentage", specs); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := Balances(groups, BalancesRequest{UUID: g.UUID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sum int64
+	for _, b := range res.Balances {
+		sum += b.Net
+	}
+
+	if sum != 0 || len(res.Transfers) != 1 || res.Transfers[0].Amount != 5e11 {
+		t.Errorf("unexpected balances %+v", res)
+	}
+}
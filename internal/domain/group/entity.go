@@ -0,0 +1,334 @@
+package group
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/split"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Group struct {
+	uuid        uuid.UUID
+	name        string
+	members     []uuid.UUID
+	expenses    []Expense
+	settlements []Settlement
+}
+
+type Expense struct {
+	UUID        uuid.UUID
+	Description string
+	Payer       uuid.UUID
+	Amount      int64
+	Date        time.Time
+	Shares      []Share
+}
+
+type Share struct {
+	User   uuid.UUID
+	Amount int64
+}
+
+// ShareSpec is how a member takes part in an expense, only the field
+// matching the split method is considered.
+type ShareSpec struct {
+	User    uuid.UUID
+	Percent float64
+	Amount  int64
+}
+
+type Settlement struct {
+	UUID   uuid.UUID
+	From   uuid.UUID
+	To     uuid.UUID
+	Amount int64
+	Date   time.Time
+}
+
+type Balance struct {
+	User uuid.UUID
+	Net  int64
+}
+
+type Method int
+
+const (
+	_ Method = iota
+
+	Equal
+	Percentage
+	Exact
+)
+
+var stringMethods = map[string]Method{
+	"equal":      Equal,
+	"percentage": Percentage,
+	"exact":      Exact,
+}
+
+func New(name string, creator uuid.UUID, members []uuid.UUID) (Group, error) {
+	var g Group
+
+	err := errors.Join(
+		g.SetName(name),
+	)
+	if err != nil {
+		return Group{}, xerrors.ErrGroupCreation.New(err)
+	}
+
+	g.members = []uuid.UUID{creator}
+	for _, m := range members {
+		if !slices.Contains(g.members, m) {
+			g.members = append(g.members, m)
+		}
+	}
+
+	g.uuid = uuid.NewUUIDv7()
+	return g, nil
+}
+
+func (g *Group) UUID() uuid.UUID              { return g.uuid }
+func (g *Group) Name() string                 { return g.name }
+func (g *Group) Members() []uuid.UUID         { return slices.Clone(g.members) }
+func (g *Group) Expenses() []Expense          { return slices.Clone(g.expenses) }
+func (g *Group) Settlements() []Settlement    { return slices.Clone(g.settlements) }
+func (g *Group) IsMember(user uuid.UUID) bool { return slices.Contains(g.members, user) }
+
+func (g *Group) SetName(name string) error { return set(&g.name, name, ProcessName) }
+
+func (g *Group) AddMember(user uuid.UUID) error {
+	if g.IsMember(user) {
+		return xerrors.ErrAlreadyGroupMember
+	}
+
+	g.members = append(g.members, user)
+	return nil
+}
+
+// RemoveMember removes a member that neither owes nor is owed
+// anything, their past expenses and settlements are kept.
+func (g *Group) RemoveMember(user uuid.UUID) error {
+	i := slices.Index(g.members, user)
+	if i < 0 {
+		return xerrors.ErrNotGroupMember
+	}
+
+	for _, b := range g.Balances() {
+		if b.User == user && b.Net != 0 {
+			return xerrors.ErrGroupUnsettled
+		}
+	}
+
+	g.members = slices.Delete(g.members, i, i+1)
+	return nil
+}
+
+func (g *Group) AddExpense(description string, payer uuid.UUID, amount int64, date time.Time, method string, specs []ShareSpec) (Expense, error) {
+	e := Expense{
+		Description: strings.TrimSpace(description),
+		Payer:       payer,
+		Amount:      amount,
+		Date:        date,
+	}
+
+	if e.Description == "" {
+		return Expense{}, xerrors.ErrDescriptionEmpty
+	}
+
+	if amount <= 0 {
+		return Expense{}, xerrors.ErrAmountNotPositive
+	}
+
+	if !g.IsMember(payer) {
+		return Expense{}, xerrors.ErrNotGroupMember
+	}
+
+	if e.Date.IsZero() {
+		e.Date = time.Now()
+	}
+
+	shares, err := g.shares(amount, method, specs)
+	if err != nil {
+		return Expense{}, err
+	}
+
+	e.Shares = shares
+	e.UUID = uuid.NewUUIDv7()
+	g.expenses = append(g.expenses, e)
+	return e, nil
+}
+
+func (g *Group) DeleteExpense(expense uuid.UUID) error {
+	i := slices.IndexFunc(g.expenses, func(e Expense) bool { return e.UUID == expense })
+	if i < 0 {
+		return xerrors.ErrExpenseNotFound
+	}
+
+	g.expenses = slices.Delete(g.expenses, i, i+1)
+	return nil
+}
+
+func (g *Group) Settle(from, to uuid.UUID, amount int64, date time.Time) (Settlement, error) {
+	if !g.IsMember(from) || !g.IsMember(to) {
+		return Settlement{}, xerrors.ErrNotGroupMember
+	}
+
+	if from == to {
+		return Settlement{}, xerrors.ErrSettleSelf
+	}
+
+	if amount <= 0 {
+		return Settlement{}, xerrors.ErrAmountNotPositive
+	}
+
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	s := Settlement{
+		UUID:   uuid.NewUUIDv7(),
+		From:   from,
+		To:     to,
+		Amount: amount,
+		Date:   date,
+	}
+
+	g.settlements = append(g.settlements, s)
+	return s, nil
+}
+
+// Balances returns the net balance of every member, positive when
+// they are owed money and negative when they owe it.
+func (g *Group) Balances() []Balance {
+	return Net(g.members, g.expenses, g.settlements)
+}
+
+// Net computes the balances of the members, followed by those of
+// former members that still owe or are owed something, which happens
+// when an expense they took part in is deleted after they left. The
+// balances always add up to zero.
+func Net(members []uuid.UUID, expenses []Expense, settlements []Settlement) []Balance {
+	net := make(map[uuid.UUID]int64, len(members))
+	users := slices.Clone(members)
+
+	add := func(user uuid.UUID, amount int64) {
+		if _, in := net[user]; !in && !slices.Contains(users, user) {
+			users = append(users, user)
+		}
+
+		net[user] += amount
+	}
+
+	for _, e := range expenses {
+		add(e.Payer, e.Amount)
+		for _, s := range e.Shares {
+			add(s.User, -s.Amount)
+		}
+	}
+
+	for _, s := range settlements {
+		add(s.From, s.Amount)
+		add(s.To, -s.Amount)
+	}
+
+	res := make([]Balance, 0, len(users))
+	for i, u := range users {
+		if i >= len(members) && net[u] == 0 {
+			continue
+		}
+
+		res = append(res, Balance{User: u, Net: net[u]})
+	}
+
+	return res
+}
+
+func (g *Group) shares(amount int64, method string, specs []ShareSpec) ([]Share, error) {
+	m, ok := stringMethods[method]
+	if !ok {
+		return nil, xerrors.ErrBadSplitMethod
+	}
+
+	// an equal split with no one given is among every member
+	if m == Equal && len(specs) == 0 {
+		for _, member := range g.members {
+			specs = append(specs, ShareSpec{User: member})
+		}
+	}
+
+	users := make([]uuid.UUID, len(specs))
+	for i, spec := range specs {
+		if !g.IsMember(spec.User) {
+			return nil, xerrors.ErrNotGroupMember
+		}
+
+		if slices.Contains(users[:i], spec.User) {
+			return nil, xerrors.ErrDuplicateShare
+		}
+
+		users[i] = spec.User
+	}
+
+	var amounts []int64
+	var err error
+
+	switch m {
+	case Equal:
+		amounts, err = split.Equal(amount, len(specs))
+
+	case Percentage:
+		percents := make([]float64, len(specs))
+		for i, spec := range specs {
+			percents[i] = spec.Percent
+		}
+
+		amounts, err = split.Percent(amount, percents)
+
+	case Exact:
+		amounts = make([]int64, len(specs))
+		for i, spec := range specs {
+			amounts[i] = spec.Amount
+		}
+
+		err = split.Exact(amount, amounts)
+	}
+	if err != nil {
+		return nil, xerrors.ErrBadSplit.New(err)
+	}
+
+	// balances only settle if every expense is split exactly, so it
+	// is checked whatever the method
+	if err := split.Exact(amount, amounts); err != nil {
+		return nil, xerrors.ErrBadSplit.New(err)
+	}
+
+	shares := make([]Share, len(specs))
+	for i := range specs {
+		shares[i] = Share{User: users[i], Amount: amounts[i]}
+	}
+
+	return shares, nil
+}
+
+func ProcessName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", xerrors.ErrNameEmpty
+	}
+
+	return name, nil
+}
+
+func set[T, R any](dst *R, src T, fn func(T) (R, error)) error {
+	val, err := fn(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = val
+	return nil
+}
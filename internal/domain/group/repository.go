@@ -0,0 +1,70 @@
+package group
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Repository interface {
+	Lister
+	Getter
+	Creater
+	Deleter
+	MemberAdder
+	MemberRemover
+	ExpenseAdder
+	ExpenseDeleter
+	Settler
+}
+
+type Lister interface {
+	List(member uuid.UUID, offset, limit int) (ListEntity, error)
+}
+
+type Getter interface {
+	Get(uuid uuid.UUID) (Entity, error)
+}
+
+type Creater interface {
+	Create(name string, creator uuid.UUID, members []uuid.UUID) (Entity, error)
+}
+
+type Deleter interface {
+	Delete(uuid uuid.UUID) error
+}
+
+type MemberAdder interface {
+	AddMember(group, user uuid.UUID) (Entity, error)
+}
+
+type MemberRemover interface {
+	RemoveMember(group, user uuid.UUID) error
+}
+
+type ExpenseAdder interface {
+	AddExpense(group uuid.UUID, description string, payer uuid.UUID, amount int64, date time.Time, method string, shares []ShareSpec) (Expense, error)
+}
+
+type ExpenseDeleter interface {
+	DeleteExpense(group, expense uuid.UUID) error
+}
+
+type Settler interface {
+	Settle(group, from, to uuid.UUID, amount int64, date time.Time) (Settlement, error)
+}
+
+type Entity struct {
+	UUID        uuid.UUID
+	Name        string
+	Members     []uuid.UUID
+	Expenses    []Expense
+	Settlements []Settlement
+}
+
+type ListEntity struct {
+	Offset       int
+	Length       int
+	Records      []Entity
+	TotalRecords int
+}
@@ -0,0 +1,194 @@
+package grouprepo
+
+import (
+	"cmp"
+	"sync"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/domain/group"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Map struct {
+	uuidIndex map[uuid.UUID]int
+
+	repo []group.Group
+	mu   sync.RWMutex
+}
+
+func NewMap() group.Repository {
+	repo := Map{
+		uuidIndex: make(map[uuid.UUID]int),
+	}
+
+	return &repo
+}
+
+func (m *Map) List(member uuid.UUID, offset, limit int) (group.ListEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var joined []*group.Group
+	for i := range m.repo {
+		if m.repo[i].IsMember(member) {
+			joined = append(joined, &m.repo[i])
+		}
+	}
+
+	lo := clamp(0, offset, len(joined))
+	hi := clamp(0, offset+limit, len(joined))
+
+	if lo >= hi {
+		return group.ListEntity{TotalRecords: len(joined)}, nil
+	}
+
+	res := make([]group.Entity, hi-lo)
+	for i, g := range joined[lo:hi] {
+		transform(&res[i], g)
+	}
+
+	return group.ListEntity{
+		Offset:       lo,
+		Length:       len(res),
+		Records:      res,
+		TotalRecords: len(joined),
+	}, nil
+}
+
+func (m *Map) Get(uuid uuid.UUID) (group.Entity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return group.Entity{}, xerrors.ErrGroupNotFound
+	}
+
+	var res group.Entity
+	transform(&res, &m.repo[index])
+	return res, nil
+}
+
+func (m *Map) Create(name string, creator uuid.UUID, members []uuid.UUID) (group.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	g, err := group.New(name, creator, members)
+	if err != nil {
+		return group.Entity{}, err
+	}
+
+	m.uuidIndex[g.UUID()] = len(m.repo)
+	m.repo = append(m.repo, g)
+
+	var res group.Entity
+	transform(&res, &g)
+	return res, nil
+}
+
+func (m *Map) Delete(uuid uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return nil
+	}
+
+	for _, b := range m.repo[index].Balances() {
+		if b.Net != 0 {
+			return xerrors.ErrGroupUnsettled
+		}
+	}
+
+	delete(m.uuidIndex, uuid)
+
+	last := len(m.repo) - 1
+	if index != last {
+		m.repo[index] = m.repo[last]
+		m.uuidIndex[m.repo[index].UUID()] = index
+	}
+
+	m.repo = m.repo[:last]
+	return nil
+}
+
+func (m *Map) AddMember(uuid, user uuid.UUID) (group.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return group.Entity{}, xerrors.ErrGroupNotFound
+	}
+
+	g := &m.repo[index]
+	if err := g.AddMember(user); err != nil {
+		return group.Entity{}, err
+	}
+
+	var res group.Entity
+	transform(&res, g)
+	return res, nil
+}
+
+func (m *Map) RemoveMember(uuid, user uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return xerrors.ErrGroupNotFound
+	}
+
+	return m.repo[index].RemoveMember(user)
+}
+
+func (m *Map) AddExpense(uuid uuid.UUID, description string, payer uuid.UUID, amount int64, date time.Time, method string, shares []group.ShareSpec) (group.Expense, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return group.Expense{}, xerrors.ErrGroupNotFound
+	}
+
+	return m.repo[index].AddExpense(description, payer, amount, date, method, shares)
+}
+
+func (m *Map) DeleteExpense(uuid, expense uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return xerrors.ErrGroupNotFound
+	}
+
+	return m.repo[index].DeleteExpense(expense)
+}
+
+func (m *Map) Settle(uuid, from, to uuid.UUID, amount int64, date time.Time) (group.Settlement, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return group.Settlement{}, xerrors.ErrGroupNotFound
+	}
+
+	return m.repo[index].Settle(from, to, amount, date)
+}
+
+func transform(r *group.Entity, g *group.Group) {
+	r.UUID = g.UUID()
+	r.Name = g.Name()
+	r.Members = g.Members()
+	r.Expenses = g.Expenses()
+	r.Settlements = g.Settlements()
+}
+
+func clamp[T cmp.Ordered](mn, val, mx T) T {
+	return min(max(mn, val), mx)
+}
@@ -0,0 +1,415 @@
+package groups
+
+import (
+	"net/http"
+
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/domain/group"
+	"github.com/alan-b-lima/prp/internal/domain/session"
+	"github.com/alan-b-lima/prp/internal/domain/user"
+	"github.com/alan-b-lima/prp/internal/support"
+	"github.com/alan-b-lima/prp/pkg/errors"
+)
+
+const _SessionCookie = "session"
+
+type Resource struct {
+	http.ServeMux
+	Groups group.Service
+	Users  user.Service
+}
+
+func New(groups group.Repository, users user.Repository, sessions session.Repository) *Resource {
+	rc := Resource{
+		Groups: *group.NewService(groups, users),
+		Users:  *user.NewService(users, sessions),
+	}
+
+	routes := map[string]http.HandlerFunc{
+		"GET /groups/":                             rc.List,
+		"GET /groups/{uuid}":                       rc.Get,
+		"POST /groups/":                            rc.Create,
+		"DELETE /groups/{uuid}":                    rc.Delete,
+		"POST /groups/{uuid}/members/":             rc.AddMember,
+		"DELETE /groups/{uuid}/members/{user}":     rc.RemoveMember,
+		"GET /groups/{uuid}/expenses/":             rc.Expenses,
+		"POST /groups/{uuid}/expenses/":            rc.AddExpense,
+		"DELETE /groups/{uuid}/expenses/{expense}": rc.DeleteExpense,
+		"GET /groups/{uuid}/balances":              rc.Balances,
+		"GET /groups/{uuid}/settlements/":          rc.Settlements,
+		"POST /groups/{uuid}/settlements/":         rc.Settle,
+	}
+
+	for route, handler := range routes {
+		rc.Handle(route, handler)
+	}
+
+	return &rc
+}
+
+func (rc *Resource) List(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	req := group.ListRequest{Offset: 0, Limit: 10}
+
+	if err := support.LimitAndOffset(
+		query.Get("offset"), query.Get("limit"),
+		&req.Offset, &req.Limit,
+	); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Groups.List(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+	if res.Records == nil {
+		// avoid "null" encoding, once v2 rolls out,
+		// this can be removed
+		res.Records = []group.Response{}
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := group.GetRequest{UUID: uuid}
+	res, err := rc.Groups.Get(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	var req group.CreateRequest
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Groups.Create(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusCreated, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := group.DeleteRequest{UUID: uuid}
+	if err := rc.Groups.Delete(ctx, req); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *Resource) AddMember(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := group.AddMemberRequest{UUID: uuid}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Groups.AddMember(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	member, err := support.UUIDFromString(r.PathValue("user"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := group.RemoveMemberRequest{UUID: uuid, User: member}
+	if err := rc.Groups.RemoveMember(ctx, req); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *Resource) Expenses(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	req := group.ExpensesRequest{UUID: uuid, Offset: 0, Limit: 10}
+
+	if err := support.LimitAndOffset(
+		query.Get("offset"), query.Get("limit"),
+		&req.Offset, &req.Limit,
+	); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Groups.Expenses(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+	if res.Records == nil {
+		// avoid "null" encoding, once v2 rolls out,
+		// this can be removed
+		res.Records = []group.ExpenseResponse{}
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) AddExpense(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := group.AddExpenseRequest{UUID: uuid}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Groups.AddExpense(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusCreated, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) DeleteExpense(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	expense, err := support.UUIDFromString(r.PathValue("expense"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := group.DeleteExpenseRequest{UUID: uuid, Expense: expense}
+	if err := rc.Groups.DeleteExpense(ctx, req); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *Resource) Balances(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := group.BalancesRequest{UUID: uuid}
+	res, err := rc.Groups.Balances(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Settlements(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := group.SettlementsRequest{UUID: uuid}
+	res, err := rc.Groups.Settlements(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Settle(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := group.SettleRequest{UUID: uuid}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Groups.Settle(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusCreated, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) session(w http.ResponseWriter, r *http.Request) (auth.Context, error) {
+	session, err := support.SessionCookie(_SessionCookie, w, r)
+	if err != nil {
+		return auth.NewUnlogged(), nil
+	}
+
+	ctx, err := rc.Users.Context(user.ContextRequest{Session: session})
+	if err, ok := errors.AsType[*errors.Error](err); ok && err.Kind.IsClient() {
+		return auth.NewUnlogged(), nil
+	}
+	if err != nil {
+		return auth.NewUnlogged(), err
+	}
+
+	return ctx, err
+}
@@ -0,0 +1,154 @@
+package group
+
+import (
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/domain/user"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Service struct {
+	Repo  Repository
+	Users user.Repository
+}
+
+func NewService(groups Repository, users user.Repository) *Service {
+	return &Service{
+		Repo:  groups,
+		Users: users,
+	}
+}
+
+var PermGeneral = auth.Permission(auth.Admin, auth.User)
+
+func (s *Service) List(ctx auth.Context, req ListRequest) (ListResponse, error) {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return ListResponse{}, xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	req.Member = ctx.User()
+	return List(s.Repo, req)
+}
+
+func (s *Service) Get(ctx auth.Context, req GetRequest) (Response, error) {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return Response{}, err
+	}
+
+	return Get(s.Repo, req)
+}
+
+func (s *Service) Create(ctx auth.Context, req CreateRequest) (Response, error) {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return Response{}, xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	req.Creator = ctx.User()
+	return Create(s.Repo, s.Users, req)
+}
+
+func (s *Service) Delete(ctx auth.Context, req DeleteRequest) error {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return err
+	}
+
+	return Delete(s.Repo, req)
+}
+
+func (s *Service) AddMember(ctx auth.Context, req AddMemberRequest) (Response, error) {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return Response{}, err
+	}
+
+	return AddMember(s.Repo, s.Users, req)
+}
+
+func (s *Service) RemoveMember(ctx auth.Context, req RemoveMemberRequest) error {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return err
+	}
+
+	return RemoveMember(s.Repo, req)
+}
+
+func (s *Service) Expenses(ctx auth.Context, req ExpensesRequest) (ExpensesResponse, error) {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return ExpensesResponse{}, err
+	}
+
+	return Expenses(s.Repo, req)
+}
+
+func (s *Service) AddExpense(ctx auth.Context, req AddExpenseRequest) (ExpenseResponse, error) {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return ExpenseResponse{}, err
+	}
+
+	if !req.Payer.Some {
+		req.Payer = opt.Some(ctx.User())
+	}
+
+	return AddExpense(s.Repo, req)
+}
+
+func (s *Service) DeleteExpense(ctx auth.Context, req DeleteExpenseRequest) error {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return err
+	}
+
+	return DeleteExpense(s.Repo, req)
+}
+
+func (s *Service) Balances(ctx auth.Context, req BalancesRequest) (BalancesResponse, error) {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return BalancesResponse{}, err
+	}
+
+	return Balances(s.Repo, req)
+}
+
+func (s *Service) Settlements(ctx auth.Context, req SettlementsRequest) (SettlementsResponse, error) {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return SettlementsResponse{}, err
+	}
+
+	return Settlements(s.Repo, req)
+}
+
+func (s *Service) Settle(ctx auth.Context, req SettleRequest) (SettlementResponse, error) {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return SettlementResponse{}, err
+	}
+
+	if !req.From.Some {
+		req.From = opt.Some(ctx.User())
+	}
+
+	// either side of a settlement may record it, but no one else
+	if ctx.User() != req.From.Val && ctx.User() != req.To {
+		return SettlementResponse{}, xerrors.ErrNotSettlementParty
+	}
+
+	return Settle(s.Repo, req)
+}
+
+// authorize allows only members of the group through.
+func (s *Service) authorize(ctx auth.Context, group uuid.UUID) error {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	res, err := s.Repo.Get(group)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range res.Members {
+		if m == ctx.User() {
+			return nil
+		}
+	}
+
+	return xerrors.ErrNotGroupMember
+}
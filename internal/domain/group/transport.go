@@ -0,0 +1,147 @@
+package group
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type (
+	ListRequest struct {
+		Member uuid.UUID `json:"-"`
+		Offset int       `json:"-"`
+		Limit  int       `json:"-"`
+	}
+
+	GetRequest struct {
+		UUID uuid.UUID `json:"-"`
+	}
+
+	CreateRequest struct {
+		Creator uuid.UUID `json:"-"`
+		Name    string    `json:"name"`
+		Members []string  `json:"members"`
+	}
+
+	DeleteRequest struct {
+		UUID uuid.UUID `json:"-"`
+	}
+
+	AddMemberRequest struct {
+		UUID  uuid.UUID `json:"-"`
+		Login string    `json:"login"`
+	}
+
+	RemoveMemberRequest struct {
+		UUID uuid.UUID `json:"-"`
+		User uuid.UUID `json:"-"`
+	}
+
+	ExpensesRequest struct {
+		UUID   uuid.UUID `json:"-"`
+		Offset int       `json:"-"`
+		Limit  int       `json:"-"`
+	}
+
+	AddExpenseRequest struct {
+		UUID        uuid.UUID          `json:"-"`
+		Description string             `json:"description"`
+		Payer       opt.Opt[uuid.UUID] `json:"payer"`
+		Amount      int64              `json:"amount"`
+		Date        time.Time          `json:"date"`
+		Split       string             `json:"split"`
+		Shares      []ShareRequest     `json:"shares"`
+	}
+
+	ShareRequest struct {
+		User    uuid.UUID `json:"user"`
+		Percent float64   `json:"percent"`
+		Amount  int64     `json:"amount"`
+	}
+
+	DeleteExpenseRequest struct {
+		UUID    uuid.UUID `json:"-"`
+		Expense uuid.UUID `json:"-"`
+	}
+
+	BalancesRequest struct {
+		UUID uuid.UUID `json:"-"`
+	}
+
+	SettlementsRequest struct {
+		UUID uuid.UUID `json:"-"`
+	}
+
+	SettleRequest struct {
+		UUID   uuid.UUID          `json:"-"`
+		From   opt.Opt[uuid.UUID] `json:"from"`
+		To     uuid.UUID          `json:"to"`
+		Amount int64              `json:"amount"`
+		Date   time.Time          `json:"date"`
+	}
+)
+
+type (
+	ListResponse struct {
+		Offset       int        `json:"offset"`
+		Length       int        `json:"length"`
+		Records      []Response `json:"records"`
+		TotalRecords int        `json:"total_records"`
+	}
+
+	Response struct {
+		UUID    uuid.UUID   `json:"uuid"`
+		Name    string      `json:"name"`
+		Members []uuid.UUID `json:"members"`
+	}
+
+	ExpensesResponse struct {
+		Offset       int               `json:"offset"`
+		Length       int               `json:"length"`
+		Records      []ExpenseResponse `json:"records"`
+		TotalRecords int               `json:"total_records"`
+	}
+
+	ExpenseResponse struct {
+		UUID        uuid.UUID       `json:"uuid"`
+		Description string          `json:"description"`
+		Payer       uuid.UUID       `json:"payer"`
+		Amount      int64           `json:"amount"`
+		Date        time.Time       `json:"date"`
+		Shares      []ShareResponse `json:"shares"`
+	}
+
+	ShareResponse struct {
+		User   uuid.UUID `json:"user"`
+		Amount int64     `json:"amount"`
+	}
+
+	BalancesResponse struct {
+		Balances  []BalanceResponse  `json:"balances"`
+		Transfers []TransferResponse `json:"transfers"`
+	}
+
+	BalanceResponse struct {
+		User uuid.UUID `json:"user"`
+		Net  int64     `json:"net"`
+	}
+
+	TransferResponse struct {
+		From   uuid.UUID `json:"from"`
+		To     uuid.UUID `json:"to"`
+		Amount int64     `json:"amount"`
+	}
+
+	SettlementsResponse struct {
+		Records []SettlementResponse `json:"records"`
+	}
+
+	SettlementResponse struct {
+		UUID   uuid.UUID `json:"uuid"`
+		From   uuid.UUID `json:"from"`
+		To     uuid.UUID `json:"to"`
+		Amount int64     `json:"amount"`
+		Date   time.Time `json:"date"`
+	}
+)
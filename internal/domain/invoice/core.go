@@ -0,0 +1,226 @@
+package invoice
+
+import (
+	"math"
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/period"
+)
+
+func List(invoices Lister, req ListRequest) (ListResponse, error) {
+	res, err := invoices.List(req.Book, req.Offset, req.Limit)
+	if err != nil {
+		return ListResponse{}, err
+	}
+
+	now := time.Now()
+
+	ares := ListResponse{
+		Offset:       res.Offset,
+		Length:       res.Length,
+		Records:      make([]Response, res.Length),
+		TotalRecords: res.TotalRecords,
+	}
+	for i := 0; i < res.Length; i++ {
+		transform(&ares.Records[i], &res.Records[i], now)
+	}
+
+	return ares, nil
+}
+
+func Get(invoices Getter, req GetRequest) (Response, error) {
+	res, err := invoices.Get(req.UUID)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res, time.Now())
+	return ares, nil
+}
+
+func Create(invoices Creater, req CreateRequest) (Response, error) {
+	res, err := invoices.Create(req.Book, req.Customer, req.Document, req.Issued, req.Due, items(req.Items))
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res, time.Now())
+	return ares, nil
+}
+
+func Patch(invoices Patcher, req PatchRequest) (Response, error) {
+	var its opt.Opt[[]Item]
+	if val, ok := req.Items.Unwrap(); ok {
+		its = opt.Some(items(val))
+	}
+
+	res, err := invoices.Patch(req.UUID, req.Customer, req.Document, req.Due, its)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res, time.Now())
+	return ares, nil
+}
+
+func Delete(invoices Deleter, req DeleteRequest) error {
+	return invoices.Delete(req.UUID)
+}
+
+func Send(invoices Sender, req SendRequest) (Response, error) {
+	res, err := invoices.Send(req.UUID)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res, time.Now())
+	return ares, nil
+}
+
+func Pay(invoices Payer, req PayRequest) (Response, error) {
+	res, err := invoices.Pay(req.UUID, req.Date, req.Amount)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res, time.Now())
+	return ares, nil
+}
+
+// agingBuckets are made of the days past the due date, those not yet
+// overdue as per StatusAt, up to the due date itself, are current.
+var agingBuckets = []struct {
+	label string
+	upTo  int // days overdue, inclusive
+}{
+	{"current", 0},
+	{"1-30", 30},
+	{"31-60", 60},
+	{"61-90", 90},
+	{"91+", math.MaxInt},
+}
+
+// Aging groups the amount outstanding at the given date, of invoices
+// issued until then, by how many days past their due date they are.
+func Aging(invoices ReceivableLister, req AgingRequest) (AgingResponse, error) {
+	res, err := invoices.Receivables(req.Book)
+	if err != nil {
+		return AgingResponse{}, err
+	}
+
+	ares := AgingResponse{
+		Date:    req.Date,
+		Buckets: make([]AgingBucketResponse, len(agingBuckets)),
+	}
+	for i, bucket := range agingBuckets {
+		ares.Buckets[i] = AgingBucketResponse{Label: bucket.label, Invoices: []AgingEntryResponse{}}
+	}
+
+	for _, inv := range res {
+		if period.DateOf(inv.Issued).After(req.Date) {
+			continue
+		}
+
+		outstanding := inv.Total
+		for _, p := range inv.Payments {
+			if !period.DateOf(p.Date).After(req.Date) {
+				outstanding -= p.Amount
+			}
+		}
+		if outstanding <= 0 {
+			continue
+		}
+
+		days := daysOverdue(inv.Due, req.Date)
+
+		for i, bucket := range agingBuckets {
+			if days > bucket.upTo {
+				continue
+			}
+
+			b := &ares.Buckets[i]
+			b.Amount += outstanding
+			b.Invoices = append(b.Invoices, AgingEntryResponse{
+				UUID:        inv.UUID,
+				Number:      inv.Number,
+				Customer:    inv.Customer,
+				Due:         inv.Due,
+				DaysOverdue: max(days, 0),
+				Outstanding: outstanding,
+			})
+			break
+		}
+
+		ares.Total += outstanding
+	}
+
+	return ares, nil
+}
+
+// StatusAt is the status of the invoice at the given time, sent
+// invoices past their due date are overdue.
+func StatusAt(e *Entity, t time.Time) Status {
+	if e.Status == Sent && daysOverdue(e.Due, period.DateOf(t)) > 0 {
+		return Overdue
+	}
+
+	return e.Status
+}
+
+// daysOverdue is the amount of days from the due date to the given
+// date, invoices are overdue only if it is positive.
+func daysOverdue(due time.Time, d period.Date) int {
+	return d.Sub(period.DateOf(due))
+}
+
+func items(req []ItemRequest) []Item {
+	res := make([]Item, len(req))
+	for i, item := range req {
+		res[i] = Item{
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+		}
+	}
+
+	return res
+}
+
+func transform(r *Response, e *Entity, now time.Time) {
+	r.UUID = e.UUID
+	r.Book = e.Book
+	r.Number = e.Number
+	r.Customer = e.Customer
+	r.Document = e.Document
+	r.Issued = e.Issued
+	r.Due = e.Due
+	r.Status = StatusAt(e, now).String()
+	r.Total = e.Total
+	r.Received = e.Received
+	r.Outstanding = e.Total - e.Received
+
+	r.Items = make([]ItemResponse, len(e.Items))
+	for i, item := range e.Items {
+		r.Items[i] = ItemResponse{
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Total:       item.Total(),
+		}
+	}
+
+	r.Payments = make([]PaymentResponse, len(e.Payments))
+	for i, p := range e.Payments {
+		r.Payments[i] = PaymentResponse{
+			UUID:   p.UUID,
+			Date:   p.Date,
+			Amount: p.Amount,
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package invoice_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/alan-b-lima/prp/internal/domain/invoice"
+	invoicerepo "github.com/alan-b-lima/prp/internal/domain/invoice/repository"
+	"github.com/alan-b-lima/prp/pkg/period"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func TestAgingBoundaries(t *testing.T) {
+	invoices := invoicerepo.NewMap()
+	book := uuid.NewUUIDv7()
+
+	issued := time.Date(2026, time.January, 2, 10, 0, 0, 0, time.UTC)
+	due := time.Date(2026, time.January, 31, 18, 0, 0, 0, time.UTC)
+
+	inv, err := invoices.Create(book, "ACME", "", issued, due, []Item{{Description: "work", Quantity: Unit, UnitPrice: 10000}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if inv, err = invoices.Send(inv.UUID); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		days   int
+		bucket string
+		status Status
+	}{
+		{0, "current", Sent},
+		{1, "1-30", Overdue},
+		{30, "1-30", Overdue},
+		{31, "31-60", Overdue},
+		{60, "31-60", Overdue},
+		{61, "61-90", Overdue},
+		{90, "61-90", Overdue},
+		{91, "91+", Overdue},
+	}
+
+	labels := []string{"current", "1-30", "31-60", "61-90", "91+"}
+
+	for _, test := range tests {
+		date := period.DateOf(due).AddDays(test.days)
+
+		res, err := Aging(invoices, AgingRequest{Book: book, Date: date})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(res.Buckets) != len(labels) {
+			t.Fatalf("expected buckets %v, got %+v", labels, res.Buckets)
+		}
+
+		var bucket string
+		for i, b := range res.Buckets {
+			if b.Label != labels[i] {
+				t.Errorf("bucket %d should be labelled %s, got %s", i, labels[i], b.Label)
+			}
+
+			if len(b.Invoices) > 0 {
+				bucket = b.Label
+			}
+		}
+
+		if bucket != test.bucket {
+			t.Errorf("%d days past due: expected bucket %s, got %s", test.days, test.bucket, bucket)
+		}
+
+		if status := StatusAt(&inv, date.Time(time.UTC)); status != test.status {
+			t.Errorf("%d days past due: expected status %v, got %v", test.days, test.status, status)
+		}
+	}
+}
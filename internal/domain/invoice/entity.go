@@ -0,0 +1,297 @@
+package invoice
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/document"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Invoice struct {
+	uuid     uuid.UUID
+	book     uuid.UUID
+	number   int
+	customer string
+	document document.Document
+	issued   time.Time
+	due      time.Time
+	items    []Item
+	status   Status
+	payments []Payment
+}
+
+type Item struct {
+	Description string
+	Quantity    Quantity
+	UnitPrice   int64
+}
+
+// Total is the quantity times the unit price, rounded to the nearest
+// minor unit, halves away from zero.
+func (i *Item) Total() int64 {
+	total := int64(i.Quantity) * i.UnitPrice
+
+	half := int64(Unit / 2)
+	if total < 0 {
+		half = -half
+	}
+
+	return (total + half) / int64(Unit)
+}
+
+// Quantity is an amount in thousandths of a unit, so that an hour and
+// a half of work is 1500. It is written as a decimal number, 1.5, in
+// JSON as well.
+type Quantity int64
+
+const Unit Quantity = 1000
+
+// Bounds of the items of an invoice, low enough that neither the total
+// of an item nor the one of the invoice can overflow.
+const (
+	MaxQuantity  = 1_000_000 * Unit
+	MaxUnitPrice = 1_000_000_000
+	MaxTotal     = 1_000_000_000_000_000
+)
+
+// ParseQuantity parses a decimal number with up to three decimal
+// places, with no exponent.
+func ParseQuantity(str string) (Quantity, error) {
+	neg := strings.HasPrefix(str, "-")
+	whole, frac, hasFrac := strings.Cut(strings.TrimPrefix(str, "-"), ".")
+
+	if whole == "" || len(frac) > 3 || hasFrac && frac == "" || len(whole) > 15 {
+		return 0, xerrors.ErrBadQuantity
+	}
+
+	var q Quantity
+	for _, c := range whole + frac + strings.Repeat("0", 3-len(frac)) {
+		if c < '0' || c > '9' {
+			return 0, xerrors.ErrBadQuantity
+		}
+
+		q = q*10 + Quantity(c-'0')
+	}
+
+	if neg {
+		q = -q
+	}
+
+	return q, nil
+}
+
+func (q Quantity) String() string {
+	sign := ""
+	if q < 0 {
+		sign, q = "-", -q
+	}
+
+	str := fmt.Sprint(sign, int64(q/Unit))
+	if frac := q % Unit; frac != 0 {
+		str += strings.TrimRight(fmt.Sprintf(".%03d", frac), "0")
+	}
+
+	return str
+}
+
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	quantity, err := ParseQuantity(string(data))
+	if err != nil {
+		return err
+	}
+
+	*q = quantity
+	return nil
+}
+
+type Payment struct {
+	UUID   uuid.UUID
+	Date   time.Time
+	Amount int64
+}
+
+type Status int
+
+const (
+	_ Status = iota
+	Draft
+	Sent
+	Paid
+
+	// Overdue is never stored, it is how sent invoices past their due
+	// date are reported.
+	Overdue
+)
+
+var statusStrings = map[Status]string{
+	Draft:   "draft",
+	Sent:    "sent",
+	Paid:    "paid",
+	Overdue: "overdue",
+}
+
+func (s Status) String() string {
+	return statusStrings[s]
+}
+
+func New(book uuid.UUID, number int, customer string, doc string, issued, due time.Time, items []Item) (Invoice, error) {
+	i := Invoice{book: book, number: number, status: Draft}
+
+	err := errors.Join(
+		i.SetCustomer(customer),
+		i.SetDocument(doc),
+		set(&i.issued, issued, ProcessIssued),
+		i.SetDue(due),
+		i.SetItems(items),
+	)
+	if err != nil {
+		return Invoice{}, xerrors.ErrInvoiceCreation.New(err)
+	}
+
+	i.uuid = uuid.NewUUIDv7()
+	return i, nil
+}
+
+func (i *Invoice) UUID() uuid.UUID             { return i.uuid }
+func (i *Invoice) Book() uuid.UUID             { return i.book }
+func (i *Invoice) Number() int                 { return i.number }
+func (i *Invoice) Customer() string            { return i.customer }
+func (i *Invoice) Document() document.Document { return i.document }
+func (i *Invoice) Issued() time.Time           { return i.issued }
+func (i *Invoice) Due() time.Time              { return i.due }
+func (i *Invoice) Items() []Item               { return slices.Clone(i.items) }
+func (i *Invoice) Status() Status              { return i.status }
+func (i *Invoice) Payments() []Payment         { return slices.Clone(i.payments) }
+
+func (i *Invoice) SetCustomer(name string) error { return set(&i.customer, name, ProcessCustomer) }
+func (i *Invoice) SetDocument(doc string) error  { return set(&i.document, doc, ProcessDocument) }
+func (i *Invoice) SetDue(due time.Time) error    { return set(&i.due, due, i.processDue) }
+func (i *Invoice) SetItems(items []Item) error   { return set(&i.items, items, ProcessItems) }
+
+func (i *Invoice) Total() int64 {
+	var total int64
+	for _, item := range i.items {
+		total += item.Total()
+	}
+
+	return total
+}
+
+func (i *Invoice) Received() int64 {
+	var received int64
+	for _, p := range i.payments {
+		received += p.Amount
+	}
+
+	return received
+}
+
+// Send issues a draft invoice, from then on it can no longer be
+// changed, only paid.
+func (i *Invoice) Send() error {
+	if i.status != Draft {
+		return xerrors.ErrInvoiceNotDraft
+	}
+
+	if i.Total() <= 0 {
+		return xerrors.ErrInvoiceEmpty
+	}
+
+	i.status = Sent
+	return nil
+}
+
+// Pay registers a payment, possibly partial, the invoice is marked as
+// paid once nothing is outstanding.
+func (i *Invoice) Pay(date time.Time, amount int64) (Payment, error) {
+	if i.status != Sent {
+		return Payment{}, xerrors.ErrInvoiceNotSent
+	}
+
+	if date.IsZero() || date.Before(i.issued) || amount <= 0 || amount > i.Total()-i.Received() {
+		return Payment{}, xerrors.ErrBadPayment
+	}
+
+	p := Payment{UUID: uuid.NewUUIDv7(), Date: date, Amount: amount}
+	i.payments = append(i.payments, p)
+
+	if i.Received() == i.Total() {
+		i.status = Paid
+	}
+
+	return p, nil
+}
+
+func ProcessCustomer(customer string) (string, error) {
+	customer = strings.TrimSpace(customer)
+	if customer == "" {
+		return "", xerrors.ErrCustomerEmpty
+	}
+
+	return customer, nil
+}
+
+func ProcessDocument(doc string) (document.Document, error) {
+	d, err := document.Parse(doc)
+	if err != nil {
+		return document.Document{}, xerrors.ErrBadDocument.New(err)
+	}
+
+	return d, nil
+}
+
+func ProcessIssued(issued time.Time) (time.Time, error) {
+	if issued.IsZero() {
+		return time.Time{}, xerrors.ErrIssuedEmpty
+	}
+
+	return issued, nil
+}
+
+func (i *Invoice) processDue(due time.Time) (time.Time, error) {
+	if due.Before(i.issued) {
+		return time.Time{}, xerrors.ErrDueBeforeIssue
+	}
+
+	return due, nil
+}
+
+func ProcessItems(items []Item) ([]Item, error) {
+	res := make([]Item, 0, len(items))
+
+	var total int64
+	for _, item := range items {
+		item.Description = strings.TrimSpace(item.Description)
+		if item.Description == "" || item.Quantity <= 0 || item.Quantity > MaxQuantity || item.UnitPrice < 0 || item.UnitPrice > MaxUnitPrice {
+			return nil, xerrors.ErrBadInvoiceItem
+		}
+
+		total += item.Total()
+		if total > MaxTotal {
+			return nil, xerrors.ErrInvoiceTooLarge
+		}
+
+		res = append(res, item)
+	}
+
+	return res, nil
+}
+
+func set[T, R any](dst *R, src T, fn func(T) (R, error)) error {
+	val, err := fn(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = val
+	return nil
+}
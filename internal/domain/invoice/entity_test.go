@@ -0,0 +1,123 @@
+package invoice_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/alan-b-lima/prp/internal/domain/invoice"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func TestQuantity(t *testing.T) {
+	tests := []struct {
+		str string
+		q   Quantity
+	}{
+		{"1", 1000},
+		{"1.5", 1500},
+		{"0.25", 250},
+		{"0.125", 125},
+		{"12.050", 12050},
+		{"-2", -2000},
+	}
+
+	for _, test := range tests {
+		q, err := ParseQuantity(test.str)
+		if err != nil {
+			t.Errorf("%s: %v", test.str, err)
+			continue
+		}
+
+		if q != test.q {
+			t.Errorf("%s: expected %d, got %d", test.str, test.q, q)
+		}
+
+		if r, _ := ParseQuantity(q.String()); r != q {
+			t.Errorf("%s: %s does not parse back to itself", test.str, q)
+		}
+	}
+
+	for _, str := range []string{"", ".5", "1.", "1.0005", "1e3", "1,5", "+1", "-", `"1"`, "1234567890123456"} {
+		if _, err := ParseQuantity(str); err == nil {
+			t.Errorf("%q should not parse", str)
+		}
+	}
+
+	var item ItemRequest
+	if err := json.Unmarshal([]byte(`{"description":"consulting","quantity":1.5,"unit_price":15000}`), &item); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.Quantity != 1500 {
+		t.Errorf("expected 1.5 to be 1500 thousandths, got %d", item.Quantity)
+	}
+
+	buf, _ := json.Marshal(ItemResponse{Quantity: 1500})
+	if string(buf) != `{"description":"","quantity":1.5,"unit_price":0,"total":0}` {
+		t.Errorf("unexpected encoding %s", buf)
+	}
+}
+
+func TestItemTotal(t *testing.T) {
+	tests := []struct {
+		q     Quantity
+		price int64
+		total int64
+	}{
+		{1500, 15000, 22500},
+		{Unit, 999, 999},
+		{333, 100, 33},
+		{335, 100, 34},
+		{125, 4, 1},
+		{1, 1, 0},
+	}
+
+	for _, test := range tests {
+		item := Item{Quantity: test.q, UnitPrice: test.price}
+		if got := item.Total(); got != test.total {
+			t.Errorf("%s × %d: expected %d, got %d", test.q, test.price, test.total, got)
+		}
+	}
+}
+
+func TestItemBounds(t *testing.T) {
+	tests := []struct {
+		items []Item
+		err   error
+	}{
+		{[]Item{{"work", MaxQuantity, MaxUnitPrice}}, nil},
+		{[]Item{{"work", MaxQuantity + 1, 1}}, xerrors.ErrBadInvoiceItem},
+		{[]Item{{"work", Unit, MaxUnitPrice + 1}}, xerrors.ErrBadInvoiceItem},
+		{[]Item{{"work", 999_999_999_999_999_000, 1 << 40}}, xerrors.ErrBadInvoiceItem},
+		{[]Item{{"work", MaxQuantity, MaxUnitPrice}, {"work", Unit, 1}}, xerrors.ErrInvoiceTooLarge},
+	}
+
+	for _, test := range tests {
+		if _, err := ProcessItems(test.items); err != test.err {
+			t.Errorf("%v: expected %v, got %v", test.items, test.err, err)
+		}
+	}
+}
+
+func TestPayBeforeIssue(t *testing.T) {
+	issued := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	i, err := New(uuid.NewUUIDv7(), 1, "ACME", "", issued, issued.AddDate(0, 0, 30), []Item{{"work", Unit, 10000}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := i.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := i.Pay(issued.AddDate(0, 0, -1), 5000); err != xerrors.ErrBadPayment {
+		t.Errorf("payment before the issue date should fail with %v, got %v", xerrors.ErrBadPayment, err)
+	}
+
+	if _, err := i.Pay(issued, 5000); err != nil {
+		t.Errorf("payment on the issue date should be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package invoice
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/document"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Repository interface {
+	Lister
+	Counter
+	Getter
+	Creater
+	Patcher
+	Deleter
+	Sender
+	Payer
+	ReceivableLister
+}
+
+type Lister interface {
+	List(book uuid.UUID, offset, limit int) (ListEntity, error)
+}
+
+// Counter counts the invoices of a book.
+type Counter interface {
+	Count(book uuid.UUID) (int, error)
+}
+
+type Getter interface {
+	Get(uuid uuid.UUID) (Entity, error)
+}
+
+type Creater interface {
+	Create(book uuid.UUID, customer string, document string, issued, due time.Time, items []Item) (Entity, error)
+}
+
+type Patcher interface {
+	Patch(uuid uuid.UUID, customer opt.Opt[string], document opt.Opt[string], due opt.Opt[time.Time], items opt.Opt[[]Item]) (Entity, error)
+}
+
+type Deleter interface {
+	Delete(uuid uuid.UUID) error
+}
+
+type Sender interface {
+	Send(uuid uuid.UUID) (Entity, error)
+}
+
+type Payer interface {
+	Pay(uuid uuid.UUID, date time.Time, amount int64) (Entity, error)
+}
+
+// ReceivableLister lists the invoices of the book that were sent,
+// paid or not.
+type ReceivableLister interface {
+	Receivables(book uuid.UUID) ([]Entity, error)
+}
+
+type Entity struct {
+	UUID     uuid.UUID
+	Book     uuid.UUID
+	Number   int
+	Customer string
+	Document document.Document
+	Issued   time.Time
+	Due      time.Time
+	Items    []Item
+	Status   Status
+	Payments []Payment
+	Total    int64
+	Received int64
+}
+
+func (e Entity) InBook() uuid.UUID { return e.Book }
+
+type ListEntity struct {
+	Offset       int
+	Length       int
+	Records      []Entity
+	TotalRecords int
+}
@@ -0,0 +1,253 @@
+package invoicerepo
+
+import (
+	"cmp"
+	"sync"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/domain/invoice"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Map struct {
+	uuidIndex map[uuid.UUID]int
+	numbers   map[uuid.UUID]int
+
+	repo []invoice.Invoice
+	mu   sync.RWMutex
+}
+
+func NewMap() invoice.Repository {
+	repo := Map{
+		uuidIndex: make(map[uuid.UUID]int),
+		numbers:   make(map[uuid.UUID]int),
+	}
+
+	return &repo
+}
+
+func (m *Map) List(book uuid.UUID, offset, limit int) (invoice.ListEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var scoped []*invoice.Invoice
+	for i := range m.repo {
+		if m.repo[i].Book() == book {
+			scoped = append(scoped, &m.repo[i])
+		}
+	}
+
+	lo := clamp(0, offset, len(scoped))
+	hi := clamp(0, offset+limit, len(scoped))
+
+	if lo >= hi {
+		return invoice.ListEntity{TotalRecords: len(scoped)}, nil
+	}
+
+	res := make([]invoice.Entity, hi-lo)
+	for i, inv := range scoped[lo:hi] {
+		transform(&res[i], inv)
+	}
+
+	return invoice.ListEntity{
+		Offset:       lo,
+		Length:       len(res),
+		Records:      res,
+		TotalRecords: len(scoped),
+	}, nil
+}
+
+func (m *Map) Count(book uuid.UUID) (int, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var count int
+	for i := range m.repo {
+		if m.repo[i].Book() == book {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (m *Map) Get(uuid uuid.UUID) (invoice.Entity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return invoice.Entity{}, xerrors.ErrInvoiceNotFound
+	}
+
+	var res invoice.Entity
+	transform(&res, &m.repo[index])
+	return res, nil
+}
+
+func (m *Map) Create(book uuid.UUID, customer string, document string, issued, due time.Time, items []invoice.Item) (invoice.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	inv, err := invoice.New(book, m.numbers[book]+1, customer, document, issued, due, items)
+	if err != nil {
+		return invoice.Entity{}, err
+	}
+
+	m.numbers[book]++
+	m.uuidIndex[inv.UUID()] = len(m.repo)
+	m.repo = append(m.repo, inv)
+
+	var res invoice.Entity
+	transform(&res, &inv)
+	return res, nil
+}
+
+func (m *Map) Patch(uuid uuid.UUID, customer opt.Opt[string], document opt.Opt[string], due opt.Opt[time.Time], items opt.Opt[[]invoice.Item]) (invoice.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return invoice.Entity{}, xerrors.ErrInvoiceNotFound
+	}
+
+	inv := m.repo[index]
+	if inv.Status() != invoice.Draft {
+		return invoice.Entity{}, xerrors.ErrInvoiceNotDraft
+	}
+
+	err := errors.Join(
+		some_then(customer, inv.SetCustomer),
+		some_then(document, inv.SetDocument),
+		some_then(due, inv.SetDue),
+		some_then(items, inv.SetItems),
+	)
+	if err != nil {
+		return invoice.Entity{}, err
+	}
+
+	m.repo[index] = inv
+
+	var res invoice.Entity
+	transform(&res, &inv)
+	return res, nil
+}
+
+func (m *Map) Delete(uuid uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return nil
+	}
+
+	if m.repo[index].Status() != invoice.Draft {
+		return xerrors.ErrInvoiceNotDraft
+	}
+
+	delete(m.uuidIndex, uuid)
+
+	last := len(m.repo) - 1
+	if index != last {
+		m.repo[index] = m.repo[last]
+		m.uuidIndex[m.repo[index].UUID()] = index
+	}
+
+	m.repo = m.repo[:last]
+	return nil
+}
+
+func (m *Map) Send(uuid uuid.UUID) (invoice.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return invoice.Entity{}, xerrors.ErrInvoiceNotFound
+	}
+
+	inv := m.repo[index]
+
+	if err := inv.Send(); err != nil {
+		return invoice.Entity{}, err
+	}
+
+	m.repo[index] = inv
+
+	var res invoice.Entity
+	transform(&res, &inv)
+	return res, nil
+}
+
+func (m *Map) Pay(uuid uuid.UUID, date time.Time, amount int64) (invoice.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return invoice.Entity{}, xerrors.ErrInvoiceNotFound
+	}
+
+	inv := m.repo[index]
+
+	if _, err := inv.Pay(date, amount); err != nil {
+		return invoice.Entity{}, err
+	}
+
+	m.repo[index] = inv
+
+	var res invoice.Entity
+	transform(&res, &inv)
+	return res, nil
+}
+
+func (m *Map) Receivables(book uuid.UUID) ([]invoice.Entity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var res []invoice.Entity
+	for i := range m.repo {
+		inv := &m.repo[i]
+		if inv.Book() != book || inv.Status() == invoice.Draft {
+			continue
+		}
+
+		var e invoice.Entity
+		transform(&e, inv)
+		res = append(res, e)
+	}
+
+	return res, nil
+}
+
+func some_then[T any](src opt.Opt[T], fn func(T) error) error {
+	if !src.Some {
+		return nil
+	}
+
+	return fn(src.Val)
+}
+
+func transform(r *invoice.Entity, i *invoice.Invoice) {
+	r.UUID = i.UUID()
+	r.Book = i.Book()
+	r.Number = i.Number()
+	r.Customer = i.Customer()
+	r.Document = i.Document()
+	r.Issued = i.Issued()
+	r.Due = i.Due()
+	r.Items = i.Items()
+	r.Status = i.Status()
+	r.Payments = i.Payments()
+	r.Total = i.Total()
+	r.Received = i.Received()
+}
+
+func clamp[T cmp.Ordered](mn, val, mx T) T {
+	return min(max(mn, val), mx)
+}
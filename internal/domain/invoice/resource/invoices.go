@@ -0,0 +1,410 @@
+package invoices
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/domain/book"
+	"github.com/alan-b-lima/prp/internal/domain/invoice"
+	"github.com/alan-b-lima/prp/internal/domain/session"
+	"github.com/alan-b-lima/prp/internal/domain/user"
+	"github.com/alan-b-lima/prp/internal/support"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/period"
+)
+
+const _SessionCookie = "session"
+
+//go:embed invoice.html
+var printHTML string
+
+var printTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"money":    money,
+	"quantity": quantity,
+	"date":     func(t time.Time) string { return t.Format("02/01/2006") },
+}).Parse(printHTML))
+
+type Resource struct {
+	http.ServeMux
+	Invoices invoice.Service
+	Users    user.Service
+}
+
+func New(invoices invoice.Repository, books book.Repository, users user.Repository, sessions session.Repository) *Resource {
+	rc := Resource{
+		Invoices: *invoice.NewService(invoices, books),
+		Users:    *user.NewService(users, sessions),
+	}
+
+	routes := map[string]http.HandlerFunc{
+		"GET /books/{book}/invoices/":                 rc.List,
+		"GET /books/{book}/invoices/aging":            rc.Aging,
+		"GET /books/{book}/invoices/{uuid}":           rc.Get,
+		"GET /books/{book}/invoices/{uuid}/print":     rc.Print,
+		"POST /books/{book}/invoices/":                rc.Create,
+		"PATCH /books/{book}/invoices/{uuid}":         rc.Patch,
+		"DELETE /books/{book}/invoices/{uuid}":        rc.Delete,
+		"POST /books/{book}/invoices/{uuid}/send":     rc.Send,
+		"POST /books/{book}/invoices/{uuid}/payments": rc.Pay,
+	}
+
+	for route, handler := range routes {
+		rc.Handle(route, handler)
+	}
+
+	return &rc
+}
+
+func (rc *Resource) List(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	req := invoice.ListRequest{Book: book, Offset: 0, Limit: 10}
+
+	if err := support.LimitAndOffset(
+		query.Get("offset"), query.Get("limit"),
+		&req.Offset, &req.Limit,
+	); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Invoices.List(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+	if res.Records == nil {
+		// avoid "null" encoding, once v2 rolls out,
+		// this can be removed
+		res.Records = []invoice.Response{}
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := invoice.GetRequest{Book: book, UUID: uuid}
+	res, err := rc.Invoices.Get(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := invoice.CreateRequest{Book: book}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Invoices.Create(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusCreated, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Patch(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := invoice.PatchRequest{Book: book, UUID: uuid}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Invoices.Patch(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := invoice.DeleteRequest{Book: book, UUID: uuid}
+	if err := rc.Invoices.Delete(ctx, req); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *Resource) Send(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := invoice.SendRequest{Book: book, UUID: uuid}
+	res, err := rc.Invoices.Send(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Pay(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := invoice.PayRequest{Book: book, UUID: uuid}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Invoices.Pay(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusCreated, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Aging(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := invoice.AgingRequest{Book: book, Date: period.Today(time.Local)}
+	if date := r.URL.Query().Get("date"); date != "" {
+		req.Date, err = support.DateFromString(date)
+		if err != nil {
+			support.WriteJsonError(w, err)
+			return
+		}
+	}
+
+	res, err := rc.Invoices.Aging(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Print(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := invoice.GetRequest{Book: book, UUID: uuid}
+	res, err := rc.Invoices.Get(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	var b bytes.Buffer
+	if err := printTemplate.Execute(&b, &res); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b.Bytes())
+}
+
+func (rc *Resource) session(w http.ResponseWriter, r *http.Request) (auth.Context, error) {
+	session, err := support.SessionCookie(_SessionCookie, w, r)
+	if err != nil {
+		return auth.NewUnlogged(), nil
+	}
+
+	ctx, err := rc.Users.Context(user.ContextRequest{Session: session})
+	if err, ok := errors.AsType[*errors.Error](err); ok && err.Kind.IsClient() {
+		return auth.NewUnlogged(), nil
+	}
+	if err != nil {
+		return auth.NewUnlogged(), err
+	}
+
+	return ctx, err
+}
+
+// money formats an amount in centavos as Brazilian reais, 123456 as
+// R$ 1.234,56.
+func money(amount int64) string {
+	// unsigned, so that the smallest int64 can be negated as well
+	sign, abs := "", uint64(amount)
+	if amount < 0 {
+		sign, abs = "-", -abs
+	}
+
+	units := fmt.Sprint(abs / 100)
+	for i := len(units) - 3; i > 0; i -= 3 {
+		units = units[:i] + "." + units[i:]
+	}
+
+	return fmt.Sprintf("%sR$ %s,%02d", sign, units, abs%100)
+}
+
+// quantity formats a quantity with a decimal comma, 1500 as 1,5.
+func quantity(q invoice.Quantity) string {
+	return strings.Replace(q.String(), ".", ",", 1)
+}
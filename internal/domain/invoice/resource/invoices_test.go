@@ -0,0 +1,26 @@
+package invoices
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		amount int64
+		want   string
+	}{
+		{0, "R$ 0,00"},
+		{5, "R$ 0,05"},
+		{123456789, "R$ 1.234.567,89"},
+		{-150, "-R$ 1,50"},
+		{math.MaxInt64, "R$ 92.233.720.368.547.758,07"},
+		{math.MinInt64, "-R$ 92.233.720.368.547.758,08"},
+	}
+
+	for _, test := range tests {
+		if got := money(test.amount); got != test.want {
+			t.Errorf("%d: expected %q, got %q", test.amount, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package invoice
+
+import (
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/domain/book"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+)
+
+type Service struct {
+	Repo  Repository
+	Books book.ContentGuard
+}
+
+func NewService(invoices Repository, books book.ContentGuard) *Service {
+	return &Service{
+		Repo:  invoices,
+		Books: books,
+	}
+}
+
+func (s *Service) List(ctx auth.Context, req ListRequest) (ListResponse, error) {
+	if err := book.Authorize(s.Books, ctx, req.Book, book.Viewer); err != nil {
+		return ListResponse{}, err
+	}
+
+	return List(s.Repo, req)
+}
+
+func (s *Service) Get(ctx auth.Context, req GetRequest) (Response, error) {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Viewer, s.Repo.Get, req.UUID, xerrors.ErrInvoiceNotFound); err != nil {
+		return Response{}, err
+	}
+
+	return Get(s.Repo, req)
+}
+
+func (s *Service) Create(ctx auth.Context, req CreateRequest) (Response, error) {
+	defer s.Books.RLockContents(req.Book)()
+
+	if err := book.Authorize(s.Books, ctx, req.Book, book.Editor); err != nil {
+		return Response{}, err
+	}
+
+	return Create(s.Repo, req)
+}
+
+func (s *Service) Patch(ctx auth.Context, req PatchRequest) (Response, error) {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Editor, s.Repo.Get, req.UUID, xerrors.ErrInvoiceNotFound); err != nil {
+		return Response{}, err
+	}
+
+	return Patch(s.Repo, req)
+}
+
+func (s *Service) Delete(ctx auth.Context, req DeleteRequest) error {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Editor, s.Repo.Get, req.UUID, xerrors.ErrInvoiceNotFound); err != nil {
+		return err
+	}
+
+	return Delete(s.Repo, req)
+}
+
+func (s *Service) Send(ctx auth.Context, req SendRequest) (Response, error) {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Editor, s.Repo.Get, req.UUID, xerrors.ErrInvoiceNotFound); err != nil {
+		return Response{}, err
+	}
+
+	return Send(s.Repo, req)
+}
+
+func (s *Service) Pay(ctx auth.Context, req PayRequest) (Response, error) {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Editor, s.Repo.Get, req.UUID, xerrors.ErrInvoiceNotFound); err != nil {
+		return Response{}, err
+	}
+
+	return Pay(s.Repo, req)
+}
+
+func (s *Service) Aging(ctx auth.Context, req AgingRequest) (AgingResponse, error) {
+	if err := book.Authorize(s.Books, ctx, req.Book, book.Viewer); err != nil {
+		return AgingResponse{}, err
+	}
+
+	return Aging(s.Repo, req)
+}
@@ -0,0 +1,128 @@
+package invoice
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/document"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/period"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type (
+	ListRequest struct {
+		Book   uuid.UUID `json:"-"`
+		Offset int       `json:"-"`
+		Limit  int       `json:"-"`
+	}
+
+	GetRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	CreateRequest struct {
+		Book     uuid.UUID     `json:"-"`
+		Customer string        `json:"customer"`
+		Document string        `json:"document"`
+		Issued   time.Time     `json:"issued"`
+		Due      time.Time     `json:"due"`
+		Items    []ItemRequest `json:"items"`
+	}
+
+	PatchRequest struct {
+		Book     uuid.UUID              `json:"-"`
+		UUID     uuid.UUID              `json:"-"`
+		Customer opt.Opt[string]        `json:"customer"`
+		Document opt.Opt[string]        `json:"document"`
+		Due      opt.Opt[time.Time]     `json:"due"`
+		Items    opt.Opt[[]ItemRequest] `json:"items"`
+	}
+
+	ItemRequest struct {
+		Description string   `json:"description"`
+		Quantity    Quantity `json:"quantity"`
+		UnitPrice   int64    `json:"unit_price"`
+	}
+
+	DeleteRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	SendRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	PayRequest struct {
+		Book   uuid.UUID `json:"-"`
+		UUID   uuid.UUID `json:"-"`
+		Date   time.Time `json:"date"`
+		Amount int64     `json:"amount"`
+	}
+
+	AgingRequest struct {
+		Book uuid.UUID   `json:"-"`
+		Date period.Date `json:"-"`
+	}
+)
+
+type (
+	ListResponse struct {
+		Offset       int        `json:"offset"`
+		Length       int        `json:"length"`
+		Records      []Response `json:"records"`
+		TotalRecords int        `json:"total_records"`
+	}
+
+	Response struct {
+		UUID        uuid.UUID         `json:"uuid"`
+		Book        uuid.UUID         `json:"book"`
+		Number      int               `json:"number"`
+		Customer    string            `json:"customer"`
+		Document    document.Document `json:"document"`
+		Issued      time.Time         `json:"issued"`
+		Due         time.Time         `json:"due"`
+		Items       []ItemResponse    `json:"items"`
+		Status      string            `json:"status"`
+		Payments    []PaymentResponse `json:"payments"`
+		Total       int64             `json:"total"`
+		Received    int64             `json:"received"`
+		Outstanding int64             `json:"outstanding"`
+	}
+
+	ItemResponse struct {
+		Description string   `json:"description"`
+		Quantity    Quantity `json:"quantity"`
+		UnitPrice   int64    `json:"unit_price"`
+		Total       int64    `json:"total"`
+	}
+
+	PaymentResponse struct {
+		UUID   uuid.UUID `json:"uuid"`
+		Date   time.Time `json:"date"`
+		Amount int64     `json:"amount"`
+	}
+
+	AgingResponse struct {
+		Date    period.Date           `json:"date"`
+		Buckets []AgingBucketResponse `json:"buckets"`
+		Total   int64                 `json:"total"`
+	}
+
+	AgingBucketResponse struct {
+		Label    string               `json:"label"`
+		Amount   int64                `json:"amount"`
+		Invoices []AgingEntryResponse `json:"invoices"`
+	}
+
+	AgingEntryResponse struct {
+		UUID        uuid.UUID `json:"uuid"`
+		Number      int       `json:"number"`
+		Customer    string    `json:"customer"`
+		Due         time.Time `json:"due"`
+		DaysOverdue int       `json:"days_overdue"`
+		Outstanding int64     `json:"outstanding"`
+	}
+)
@@ -0,0 +1,141 @@
+package loan
+
+import (
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/amortization"
+	"github.com/alan-b-lima/prp/pkg/period"
+)
+
+func List(loans Lister, req ListRequest) (ListResponse, error) {
+	res, err := loans.List(req.Book, req.Offset, req.Limit)
+	if err != nil {
+		return ListResponse{}, err
+	}
+
+	ares := ListResponse{
+		Offset:       res.Offset,
+		Length:       res.Length,
+		Records:      make([]Response, res.Length),
+		TotalRecords: res.TotalRecords,
+	}
+	for i := 0; i < res.Length; i++ {
+		transform(&ares.Records[i], &res.Records[i])
+	}
+
+	return ares, nil
+}
+
+func Get(loans Getter, req GetRequest) (Response, error) {
+	res, err := loans.Get(req.UUID)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Create(loans Creater, req CreateRequest) (Response, error) {
+	res, err := loans.Create(req.Book, req.Name, req.Principal, req.Rate, req.Term, req.System, req.Start)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Patch(loans Patcher, req PatchRequest) (Response, error) {
+	res, err := loans.Patch(req.UUID, req.Name)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Delete(loans Deleter, req DeleteRequest) error {
+	return loans.Delete(req.UUID)
+}
+
+func Schedule(loans Getter, req ScheduleRequest) (ScheduleResponse, error) {
+	res, err := loans.Get(req.UUID)
+	if err != nil {
+		return ScheduleResponse{}, err
+	}
+
+	rows, err := amortization.Schedule(amortization.Loan{
+		Principal: res.Principal,
+		Rate:      res.Rate,
+		Term:      res.Term,
+		System:    res.System,
+		Extra:     res.ExtraPayments,
+	})
+	if err != nil {
+		return ScheduleResponse{}, xerrors.ErrBadLoanTerms.New(err)
+	}
+
+	ares := ScheduleResponse{
+		Loan:         res.UUID,
+		Installments: make([]InstallmentResponse, 0, len(rows)),
+	}
+	start := period.DateOf(res.Start)
+	for _, row := range rows {
+		// installments of loans started at the end of a month fall due
+		// at the end of the shorter months
+		due := start.AddMonths(row.Period - 1)
+		if !req.Period.IsZero() && !req.Period.Contains(due) {
+			continue
+		}
+
+		ares.Installments = append(ares.Installments, InstallmentResponse{
+			Period:       row.Period,
+			Due:          due,
+			Payment:      row.Payment,
+			Interest:     row.Interest,
+			Amortization: row.Amortization,
+			Extra:        row.Extra,
+			Balance:      row.Balance,
+		})
+
+		ares.TotalInterest += row.Interest
+		ares.TotalPaid += row.Payment
+	}
+
+	return ares, nil
+}
+
+func AddExtraPayment(loans ExtraPayer, req ExtraPaymentRequest) (Response, error) {
+	res, err := loans.AddExtraPayment(req.UUID, req.Period, req.Amount, req.Reduce)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func transform(r *Response, e *Entity) {
+	r.UUID = e.UUID
+	r.Book = e.Book
+	r.Name = e.Name
+	r.Principal = e.Principal
+	r.Rate = e.Rate
+	r.Term = e.Term
+	r.System = e.System.String()
+	r.Start = e.Start
+
+	r.ExtraPayments = make([]ExtraPaymentResponse, len(e.ExtraPayments))
+	for i, extra := range e.ExtraPayments {
+		r.ExtraPayments[i] = ExtraPaymentResponse{
+			Period: extra.Period,
+			Amount: extra.Amount,
+			Reduce: extra.Reduce.String(),
+		}
+	}
+}
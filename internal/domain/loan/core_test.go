@@ -0,0 +1,134 @@
+package loan_test
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/alan-b-lima/prp/internal/domain/loan"
+	loanrepo "github.com/alan-b-lima/prp/internal/domain/loan/repository"
+	"github.com/alan-b-lima/prp/pkg/amortization"
+	"github.com/alan-b-lima/prp/pkg/period"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func TestScheduleMonthEnd(t *testing.T) {
+	loans := loanrepo.NewMap()
+
+	start := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	l, err := loans.Create(uuid.NewUUIDv7(), "car", 1200000, 0.01, 12, "price", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := Schedule(loans, ScheduleRequest{Book: l.Book, UUID: l.UUID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	due := []string{
+		"2026-01-31", "2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31", "2026-06-30",
+		"2026-07-31", "2026-08-31", "2026-09-30", "2026-10-31", "2026-11-30", "2026-12-31",
+	}
+
+	if len(res.Installments) != len(due) {
+		t.Fatalf("expected %d installments, got %d", len(due), len(res.Installments))
+	}
+
+	for i, inst := range res.Installments {
+		if inst.Due.String() != due[i] {
+			t.Errorf("installment %d should be due on %s, got %s", inst.Period, due[i], inst.Due)
+		}
+	}
+}
+
+func TestSchedulePeriod(t *testing.T) {
+	loans := loanrepo.NewMap()
+
+	start := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	l, err := loans.Create(uuid.NewUUIDv7(), "car", 1200000, 0.01, 12, "price", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		period string
+		due    []string
+	}{
+		{"2026-02", []string{"2026-02-28"}},
+		{"2026-03", []string{"2026-03-31"}},
+		{"2026-Q2", []string{"2026-04-30", "2026-05-31", "2026-06-30"}},
+		{"2027", nil},
+	}
+
+	for _, test := range tests {
+		p, err := period.Parse(test.period)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := Schedule(loans, ScheduleRequest{Book: l.Book, UUID: l.UUID, Period: p})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var due []string
+		var paid int64
+		for _, inst := range res.Installments {
+			due = append(due, inst.Due.String())
+			paid += inst.Payment
+		}
+
+		if !slices.Equal(due, test.due) {
+			t.Errorf("%s: expected installments due on %v, got %v", test.period, test.due, due)
+		}
+
+		if res.TotalPaid != paid {
+			t.Errorf("%s: total paid should cover only the listed installments, %d != %d", test.period, res.TotalPaid, paid)
+		}
+	}
+}
+
+func TestTermBound(t *testing.T) {
+	loans := loanrepo.NewMap()
+
+	for _, term := range []int{0, amortization.MaxTerm + 1, 1 << 40} {
+		if _, err := ProcessTerm(term); !errors.Is(err, amortization.ErrBadTerm) {
+			t.Errorf("term %d should have been rejected, got %v", term, err)
+		}
+	}
+
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := loans.Create(uuid.NewUUIDv7(), "house", 100000, 0.01, 1<<40, "sac", start); err == nil {
+		t.Error("loan with an unbounded term should not have been created")
+	}
+
+	l, err := loans.Create(uuid.NewUUIDv7(), "house", 2, 0.001, 360, "price", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loans.AddExtraPayment(l.UUID, 1, 1, "term"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Schedule(loans, ScheduleRequest{Book: l.Book, UUID: l.UUID}); !errors.Is(err, amortization.ErrNoAmortization) {
+		t.Errorf("expected %v, got %v", amortization.ErrNoAmortization, err)
+	}
+}
+
+func TestPrincipalAndRateBounds(t *testing.T) {
+	for _, principal := range []int64{0, amortization.MaxPrincipal + 1, math.MaxInt64} {
+		if _, err := ProcessPrincipal(principal); !errors.Is(err, amortization.ErrBadPrincipal) {
+			t.Errorf("principal %d should have been rejected, got %v", principal, err)
+		}
+	}
+
+	for _, rate := range []float64{-0.01, amortization.MaxRate + 0.01, 1e300, math.Inf(1), math.NaN()} {
+		if _, err := ProcessRate(rate); !errors.Is(err, amortization.ErrBadRate) {
+			t.Errorf("rate %v should have been rejected, got %v", rate, err)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package loan
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/amortization"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Loan struct {
+	uuid      uuid.UUID
+	book      uuid.UUID
+	name      string
+	principal int64
+	rate      float64
+	term      int
+	system    amortization.System
+	start     time.Time
+	extras    []amortization.Extra
+}
+
+func New(book uuid.UUID, name string, principal int64, rate float64, term int, system string, start time.Time) (Loan, error) {
+	l := Loan{book: book}
+
+	err := errors.Join(
+		l.SetName(name),
+		set(&l.principal, principal, ProcessPrincipal),
+		set(&l.rate, rate, ProcessRate),
+		set(&l.term, term, ProcessTerm),
+		set(&l.system, system, ProcessSystem),
+		set(&l.start, start, ProcessStart),
+	)
+	if err != nil {
+		return Loan{}, xerrors.ErrLoanCreation.New(err)
+	}
+
+	l.uuid = uuid.NewUUIDv7()
+	return l, nil
+}
+
+func (l *Loan) UUID() uuid.UUID                     { return l.uuid }
+func (l *Loan) Book() uuid.UUID                     { return l.book }
+func (l *Loan) Name() string                        { return l.name }
+func (l *Loan) Principal() int64                    { return l.principal }
+func (l *Loan) Rate() float64                       { return l.rate }
+func (l *Loan) Term() int                           { return l.term }
+func (l *Loan) System() amortization.System         { return l.system }
+func (l *Loan) Start() time.Time                    { return l.start }
+func (l *Loan) ExtraPayments() []amortization.Extra { return slices.Clone(l.extras) }
+
+func (l *Loan) SetName(name string) error { return set(&l.name, name, ProcessName) }
+
+// AddExtraPayment registers an extra payment, to be paid along the
+// installment of the given period.
+func (l *Loan) AddExtraPayment(period int, amount int64, reduce string) error {
+	r, err := amortization.ParseReduce(reduce)
+	if err != nil {
+		return xerrors.ErrBadExtraPayment.New(err)
+	}
+
+	extra := amortization.Extra{Period: period, Amount: amount, Reduce: r}
+	if err := extra.Validate(l.term); err != nil {
+		return xerrors.ErrBadExtraPayment.New(err)
+	}
+
+	l.extras = append(l.extras, extra)
+	return nil
+}
+
+func ProcessName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", xerrors.ErrNameEmpty
+	}
+
+	return name, nil
+}
+
+func ProcessPrincipal(principal int64) (int64, error) {
+	if principal <= 0 || principal > amortization.MaxPrincipal {
+		return 0, xerrors.ErrBadLoanTerms.New(amortization.ErrBadPrincipal)
+	}
+
+	return principal, nil
+}
+
+func ProcessRate(rate float64) (float64, error) {
+	if !(rate >= 0 && rate <= amortization.MaxRate) {
+		return 0, xerrors.ErrBadLoanTerms.New(amortization.ErrBadRate)
+	}
+
+	return rate, nil
+}
+
+func ProcessTerm(term int) (int, error) {
+	if term <= 0 || term > amortization.MaxTerm {
+		return 0, xerrors.ErrBadLoanTerms.New(amortization.ErrBadTerm)
+	}
+
+	return term, nil
+}
+
+func ProcessSystem(system string) (amortization.System, error) {
+	s, err := amortization.ParseSystem(system)
+	if err != nil {
+		return 0, xerrors.ErrBadLoanTerms.New(err)
+	}
+
+	return s, nil
+}
+
+func ProcessStart(start time.Time) (time.Time, error) {
+	if start.IsZero() {
+		return time.Time{}, xerrors.ErrLoanStartEmpty
+	}
+
+	return start, nil
+}
+
+func set[T, R any](dst *R, src T, fn func(T) (R, error)) error {
+	val, err := fn(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = val
+	return nil
+}
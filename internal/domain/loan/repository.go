@@ -0,0 +1,69 @@
+package loan
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/amortization"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Repository interface {
+	Lister
+	Counter
+	Getter
+	Creater
+	Patcher
+	Deleter
+	ExtraPayer
+}
+
+type Lister interface {
+	List(book uuid.UUID, offset, limit int) (ListEntity, error)
+}
+
+// Counter counts the loans of a book.
+type Counter interface {
+	Count(book uuid.UUID) (int, error)
+}
+
+type Getter interface {
+	Get(uuid uuid.UUID) (Entity, error)
+}
+
+type Creater interface {
+	Create(book uuid.UUID, name string, principal int64, rate float64, term int, system string, start time.Time) (Entity, error)
+}
+
+type Patcher interface {
+	Patch(uuid uuid.UUID, name opt.Opt[string]) (Entity, error)
+}
+
+type Deleter interface {
+	Delete(uuid uuid.UUID) error
+}
+
+type ExtraPayer interface {
+	AddExtraPayment(uuid uuid.UUID, period int, amount int64, reduce string) (Entity, error)
+}
+
+type Entity struct {
+	UUID          uuid.UUID
+	Book          uuid.UUID
+	Name          string
+	Principal     int64
+	Rate          float64
+	Term          int
+	System        amortization.System
+	Start         time.Time
+	ExtraPayments []amortization.Extra
+}
+
+func (e Entity) InBook() uuid.UUID { return e.Book }
+
+type ListEntity struct {
+	Offset       int
+	Length       int
+	Records      []Entity
+	TotalRecords int
+}
@@ -0,0 +1,192 @@
+package loanrepo
+
+import (
+	"cmp"
+	"sync"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/domain/loan"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Map struct {
+	uuidIndex map[uuid.UUID]int
+
+	repo []loan.Loan
+	mu   sync.RWMutex
+}
+
+func NewMap() loan.Repository {
+	repo := Map{
+		uuidIndex: make(map[uuid.UUID]int),
+	}
+
+	return &repo
+}
+
+func (m *Map) List(book uuid.UUID, offset, limit int) (loan.ListEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var scoped []*loan.Loan
+	for i := range m.repo {
+		if m.repo[i].Book() == book {
+			scoped = append(scoped, &m.repo[i])
+		}
+	}
+
+	lo := clamp(0, offset, len(scoped))
+	hi := clamp(0, offset+limit, len(scoped))
+
+	if lo >= hi {
+		return loan.ListEntity{TotalRecords: len(scoped)}, nil
+	}
+
+	res := make([]loan.Entity, hi-lo)
+	for i, l := range scoped[lo:hi] {
+		transform(&res[i], l)
+	}
+
+	return loan.ListEntity{
+		Offset:       lo,
+		Length:       len(res),
+		Records:      res,
+		TotalRecords: len(scoped),
+	}, nil
+}
+
+func (m *Map) Count(book uuid.UUID) (int, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var count int
+	for i := range m.repo {
+		if m.repo[i].Book() == book {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (m *Map) Get(uuid uuid.UUID) (loan.Entity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return loan.Entity{}, xerrors.ErrLoanNotFound
+	}
+
+	var res loan.Entity
+	transform(&res, &m.repo[index])
+	return res, nil
+}
+
+func (m *Map) Create(book uuid.UUID, name string, principal int64, rate float64, term int, system string, start time.Time) (loan.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	l, err := loan.New(book, name, principal, rate, term, system, start)
+	if err != nil {
+		return loan.Entity{}, err
+	}
+
+	m.uuidIndex[l.UUID()] = len(m.repo)
+	m.repo = append(m.repo, l)
+
+	var res loan.Entity
+	transform(&res, &l)
+	return res, nil
+}
+
+func (m *Map) Patch(uuid uuid.UUID, name opt.Opt[string]) (loan.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return loan.Entity{}, xerrors.ErrLoanNotFound
+	}
+
+	l := m.repo[index]
+
+	if err := some_then(name, l.SetName); err != nil {
+		return loan.Entity{}, err
+	}
+
+	m.repo[index] = l
+
+	var res loan.Entity
+	transform(&res, &l)
+	return res, nil
+}
+
+func (m *Map) Delete(uuid uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return nil
+	}
+
+	delete(m.uuidIndex, uuid)
+
+	last := len(m.repo) - 1
+	if index != last {
+		m.repo[index] = m.repo[last]
+		m.uuidIndex[m.repo[index].UUID()] = index
+	}
+
+	m.repo = m.repo[:last]
+	return nil
+}
+
+func (m *Map) AddExtraPayment(uuid uuid.UUID, period int, amount int64, reduce string) (loan.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return loan.Entity{}, xerrors.ErrLoanNotFound
+	}
+
+	l := m.repo[index]
+
+	if err := l.AddExtraPayment(period, amount, reduce); err != nil {
+		return loan.Entity{}, err
+	}
+
+	m.repo[index] = l
+
+	var res loan.Entity
+	transform(&res, &l)
+	return res, nil
+}
+
+func some_then[T any](src opt.Opt[T], fn func(T) error) error {
+	if !src.Some {
+		return nil
+	}
+
+	return fn(src.Val)
+}
+
+func transform(r *loan.Entity, l *loan.Loan) {
+	r.UUID = l.UUID()
+	r.Book = l.Book()
+	r.Name = l.Name()
+	r.Principal = l.Principal()
+	r.Rate = l.Rate()
+	r.Term = l.Term()
+	r.System = l.System()
+	r.Start = l.Start()
+	r.ExtraPayments = l.ExtraPayments()
+}
+
+func clamp[T cmp.Ordered](mn, val, mx T) T {
+	return min(max(mn, val), mx)
+}
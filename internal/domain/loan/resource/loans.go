@@ -0,0 +1,306 @@
+package loans
+
+import (
+	"net/http"
+
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/domain/book"
+	"github.com/alan-b-lima/prp/internal/domain/loan"
+	"github.com/alan-b-lima/prp/internal/domain/session"
+	"github.com/alan-b-lima/prp/internal/domain/user"
+	"github.com/alan-b-lima/prp/internal/support"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/period"
+)
+
+const _SessionCookie = "session"
+
+type Resource struct {
+	http.ServeMux
+	Loans loan.Service
+	Users user.Service
+}
+
+func New(loans loan.Repository, books book.Repository, users user.Repository, sessions session.Repository) *Resource {
+	rc := Resource{
+		Loans: *loan.NewService(loans, books),
+		Users: *user.NewService(users, sessions),
+	}
+
+	routes := map[string]http.HandlerFunc{
+		"GET /books/{book}/loans/":                       rc.List,
+		"GET /books/{book}/loans/{uuid}":                 rc.Get,
+		"POST /books/{book}/loans/":                      rc.Create,
+		"PATCH /books/{book}/loans/{uuid}":               rc.Patch,
+		"DELETE /books/{book}/loans/{uuid}":              rc.Delete,
+		"GET /books/{book}/loans/{uuid}/schedule":        rc.Schedule,
+		"POST /books/{book}/loans/{uuid}/extra-payments": rc.AddExtraPayment,
+	}
+
+	for route, handler := range routes {
+		rc.Handle(route, handler)
+	}
+
+	return &rc
+}
+
+func (rc *Resource) List(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	req := loan.ListRequest{Book: book, Offset: 0, Limit: 10}
+
+	if err := support.LimitAndOffset(
+		query.Get("offset"), query.Get("limit"),
+		&req.Offset, &req.Limit,
+	); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Loans.List(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+	if res.Records == nil {
+		// avoid "null" encoding, once v2 rolls out,
+		// this can be removed
+		res.Records = []loan.Response{}
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := loan.GetRequest{Book: book, UUID: uuid}
+	res, err := rc.Loans.Get(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := loan.CreateRequest{Book: book}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Loans.Create(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusCreated, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Patch(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := loan.PatchRequest{Book: book, UUID: uuid}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Loans.Patch(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := loan.DeleteRequest{Book: book, UUID: uuid}
+	if err := rc.Loans.Delete(ctx, req); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *Resource) Schedule(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	p, err := support.PeriodFromString(r.URL.Query().Get("period"), period.Calendar{})
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := loan.ScheduleRequest{Book: book, UUID: uuid, Period: p}
+	res, err := rc.Loans.Schedule(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusOK, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) AddExtraPayment(w http.ResponseWriter, r *http.Request) {
+	ctx, err := rc.session(w, r)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	book, err := support.UUIDFromString(r.PathValue("book"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	uuid, err := support.UUIDFromString(r.PathValue("uuid"))
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	req := loan.ExtraPaymentRequest{Book: book, UUID: uuid}
+	if err := support.DecodeJSON(&req, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	res, err := rc.Loans.AddExtraPayment(ctx, req)
+	if err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+
+	if err := support.EncodeJSON(&res, http.StatusCreated, w, r); err != nil {
+		support.WriteJsonError(w, err)
+		return
+	}
+}
+
+func (rc *Resource) session(w http.ResponseWriter, r *http.Request) (auth.Context, error) {
+	session, err := support.SessionCookie(_SessionCookie, w, r)
+	if err != nil {
+		return auth.NewUnlogged(), nil
+	}
+
+	ctx, err := rc.Users.Context(user.ContextRequest{Session: session})
+	if err, ok := errors.AsType[*errors.Error](err); ok && err.Kind.IsClient() {
+		return auth.NewUnlogged(), nil
+	}
+	if err != nil {
+		return auth.NewUnlogged(), err
+	}
+
+	return ctx, err
+}
@@ -0,0 +1,77 @@
+package loan
+
+import (
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/domain/book"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+)
+
+type Service struct {
+	Repo  Repository
+	Books book.ContentGuard
+}
+
+func NewService(loans Repository, books book.ContentGuard) *Service {
+	return &Service{
+		Repo:  loans,
+		Books: books,
+	}
+}
+
+func (s *Service) List(ctx auth.Context, req ListRequest) (ListResponse, error) {
+	if err := book.Authorize(s.Books, ctx, req.Book, book.Viewer); err != nil {
+		return ListResponse{}, err
+	}
+
+	return List(s.Repo, req)
+}
+
+func (s *Service) Get(ctx auth.Context, req GetRequest) (Response, error) {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Viewer, s.Repo.Get, req.UUID, xerrors.ErrLoanNotFound); err != nil {
+		return Response{}, err
+	}
+
+	return Get(s.Repo, req)
+}
+
+func (s *Service) Create(ctx auth.Context, req CreateRequest) (Response, error) {
+	defer s.Books.RLockContents(req.Book)()
+
+	if err := book.Authorize(s.Books, ctx, req.Book, book.Editor); err != nil {
+		return Response{}, err
+	}
+
+	return Create(s.Repo, req)
+}
+
+func (s *Service) Patch(ctx auth.Context, req PatchRequest) (Response, error) {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Editor, s.Repo.Get, req.UUID, xerrors.ErrLoanNotFound); err != nil {
+		return Response{}, err
+	}
+
+	return Patch(s.Repo, req)
+}
+
+func (s *Service) Delete(ctx auth.Context, req DeleteRequest) error {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Editor, s.Repo.Get, req.UUID, xerrors.ErrLoanNotFound); err != nil {
+		return err
+	}
+
+	return Delete(s.Repo, req)
+}
+
+func (s *Service) Schedule(ctx auth.Context, req ScheduleRequest) (ScheduleResponse, error) {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Viewer, s.Repo.Get, req.UUID, xerrors.ErrLoanNotFound); err != nil {
+		return ScheduleResponse{}, err
+	}
+
+	return Schedule(s.Repo, req)
+}
+
+func (s *Service) AddExtraPayment(ctx auth.Context, req ExtraPaymentRequest) (Response, error) {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Editor, s.Repo.Get, req.UUID, xerrors.ErrLoanNotFound); err != nil {
+		return Response{}, err
+	}
+
+	return AddExtraPayment(s.Repo, req)
+}
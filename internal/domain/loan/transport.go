@@ -0,0 +1,101 @@
+package loan
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/period"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type (
+	ListRequest struct {
+		Book   uuid.UUID `json:"-"`
+		Offset int       `json:"-"`
+		Limit  int       `json:"-"`
+	}
+
+	GetRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	CreateRequest struct {
+		Book      uuid.UUID `json:"-"`
+		Name      string    `json:"name"`
+		Principal int64     `json:"principal"`
+		Rate      float64   `json:"rate"`
+		Term      int       `json:"term"`
+		System    string    `json:"system"`
+		Start     time.Time `json:"start"`
+	}
+
+	PatchRequest struct {
+		Book uuid.UUID       `json:"-"`
+		UUID uuid.UUID       `json:"-"`
+		Name opt.Opt[string] `json:"name"`
+	}
+
+	DeleteRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	ScheduleRequest struct {
+		Book   uuid.UUID     `json:"-"`
+		UUID   uuid.UUID     `json:"-"`
+		Period period.Period `json:"-"`
+	}
+
+	ExtraPaymentRequest struct {
+		Book   uuid.UUID `json:"-"`
+		UUID   uuid.UUID `json:"-"`
+		Period int       `json:"period"`
+		Amount int64     `json:"amount"`
+		Reduce string    `json:"reduce"`
+	}
+)
+
+type (
+	ListResponse struct {
+		Offset       int        `json:"offset"`
+		Length       int        `json:"length"`
+		Records      []Response `json:"records"`
+		TotalRecords int        `json:"total_records"`
+	}
+
+	Response struct {
+		UUID          uuid.UUID              `json:"uuid"`
+		Book          uuid.UUID              `json:"book"`
+		Name          string                 `json:"name"`
+		Principal     int64                  `json:"principal"`
+		Rate          float64                `json:"rate"`
+		Term          int                    `json:"term"`
+		System        string                 `json:"system"`
+		Start         time.Time              `json:"start"`
+		ExtraPayments []ExtraPaymentResponse `json:"extra_payments"`
+	}
+
+	ExtraPaymentResponse struct {
+		Period int    `json:"period"`
+		Amount int64  `json:"amount"`
+		Reduce string `json:"reduce"`
+	}
+
+	ScheduleResponse struct {
+		Loan          uuid.UUID             `json:"loan"`
+		Installments  []InstallmentResponse `json:"installments"`
+		TotalInterest int64                 `json:"total_interest"`
+		TotalPaid     int64                 `json:"total_paid"`
+	}
+
+	InstallmentResponse struct {
+		Period       int         `json:"period"`
+		Due          period.Date `json:"due"`
+		Payment      int64       `json:"payment"`
+		Interest     int64       `json:"interest"`
+		Amortization int64       `json:"amortization"`
+		Extra        int64       `json:"extra"`
+		Balance      int64       `json:"balance"`
+	}
+)
@@ -0,0 +1,238 @@
+package notification
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func List(notifications Lister, req ListRequest) (ListResponse, error) {
+	res, err := notifications.List(req.User, req.Unread, req.Offset, req.Limit)
+	if err != nil {
+		return ListResponse{}, err
+	}
+
+	ares := ListResponse{
+		Offset:       res.Offset,
+		Length:       res.Length,
+		Records:      make([]Response, res.Length),
+		TotalRecords: res.TotalRecords,
+	}
+	for i := 0; i < res.Length; i++ {
+		transform(&ares.Records[i], &res.Records[i])
+	}
+
+	return ares, nil
+}
+
+func Get(notifications Getter, req GetRequest) (Response, error) {
+	res, err := notifications.Get(req.UUID)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Patch(notifications Patcher, req PatchRequest) (Response, error) {
+	res, err := notifications.Patch(req.UUID, req.Read)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Delete(notifications Deleter, req DeleteRequest) error {
+	return notifications.Delete(req.UUID)
+}
+
+func GetPreferences(notifications PreferencesGetter, req PreferencesRequest) (PreferencesResponse, error) {
+	res, err := notifications.Preferences(req.User)
+	if err != nil {
+		return PreferencesResponse{}, err
+	}
+
+	var ares PreferencesResponse
+	transformPreferences(&ares, &res)
+	return ares, nil
+}
+
+func PatchPreferences(notifications PreferencesPatcher, req PatchPreferencesRequest) (PreferencesResponse, error) {
+	res, err := notifications.PatchPreferences(req.User, req.Timezone, req.Channels, req.Email, req.Webhook)
+	if err != nil {
+		return PreferencesResponse{}, err
+	}
+
+	var ares PreferencesResponse
+	transformPreferences(&ares, &res)
+	return ares, nil
+}
+
+func Reminders(notifications ReminderLister, req RemindersRequest) (ReminderListResponse, error) {
+	res, err := notifications.Reminders(req.User, req.Offset, req.Limit)
+	if err != nil {
+		return ReminderListResponse{}, err
+	}
+
+	ares := ReminderListResponse{
+		Offset:       res.Offset,
+		Length:       res.Length,
+		Records:      make([]ReminderResponse, res.Length),
+		TotalRecords: res.TotalRecords,
+	}
+	for i := 0; i < res.Length; i++ {
+		transformReminder(&ares.Records[i], &res.Records[i])
+	}
+
+	return ares, nil
+}
+
+type reminderCreater interface {
+	PreferencesGetter
+	ReminderCreater
+}
+
+// CreateReminder creates a reminder at the given local time, in the
+// timezone of the user at the moment of creation.
+func CreateReminder(notifications reminderCreater, req CreateReminderRequest) (ReminderResponse, error) {
+	prefs, err := notifications.Preferences(req.User)
+	if err != nil {
+		return ReminderResponse{}, err
+	}
+
+	at, err := time.ParseInLocation(LocalTime, req.At, prefs.Location)
+	if err != nil {
+		return ReminderResponse{}, xerrors.ErrReminderCreation.New(xerrors.ErrBadReminderTime.New(err))
+	}
+
+	res, err := notifications.CreateReminder(req.User, req.Title, req.Body, at, req.Repeat)
+	if err != nil {
+		return ReminderResponse{}, err
+	}
+
+	var ares ReminderResponse
+	transformReminder(&ares, &res)
+	return ares, nil
+}
+
+func DeleteReminder(notifications ReminderDeleter, req DeleteReminderRequest) error {
+	return notifications.DeleteReminder(req.UUID)
+}
+
+// Notify delivers the message to the user through every channel
+// enabled in their preferences, it is the entry point for anything
+// that wants to reach a user.
+func Notify(notifications PreferencesGetter, notifiers map[Channel]Notifier, user uuid.UUID, msg Message) error {
+	prefs, err := notifications.Preferences(user)
+	if err != nil {
+		return err
+	}
+
+	to := Recipient{
+		User:    prefs.User,
+		Email:   prefs.Email,
+		Webhook: prefs.Webhook,
+	}
+
+	// the cause of failures is only logged, as it may tell about the
+	// network of the server
+	var failed []string
+	for _, c := range prefs.Channels {
+		notifier, ok := notifiers[c]
+		if !ok {
+			continue
+		}
+
+		if err := notifier.Notify(to, msg); err != nil {
+			log.Printf("notification to %v through %v: %v\n", user, c, err)
+			failed = append(failed, c.String())
+		}
+	}
+
+	if len(failed) > 0 {
+		return xerrors.ErrDeliveryFailed.New(strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func Test(notifications PreferencesGetter, notifiers map[Channel]Notifier, req TestRequest) error {
+	return Notify(notifications, notifiers, req.User, Message{
+		Title: "Test notification",
+		Body:  "Notifications are reaching you through this channel.",
+		Time:  time.Now(),
+	})
+}
+
+type reminderFirer interface {
+	PreferencesGetter
+	ReminderGetter
+	ReminderAdvancer
+}
+
+// Fire notifies the owner of the reminder scheduled at the given time
+// and advances it, the next occurrence is returned if there is one.
+// Reminders deleted or rescheduled since are ignored.
+func Fire(notifications reminderFirer, notifiers map[Channel]Notifier, reminder uuid.UUID, at time.Time) (time.Time, bool, error) {
+	res, err := notifications.Reminder(reminder)
+	if err == xerrors.ErrReminderNotFound {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	if !res.Next.Equal(at) {
+		return time.Time{}, false, nil
+	}
+
+	nerr := Notify(notifications, notifiers, res.User, Message{
+		Title: res.Title,
+		Body:  res.Body,
+		Time:  res.Next,
+	})
+
+	next, more, err := notifications.AdvanceReminder(reminder, time.Now())
+	if err != nil {
+		return time.Time{}, false, errors.Join(nerr, err)
+	}
+
+	return next.Next, more, nerr
+}
+
+func transform(r *Response, e *Entity) {
+	r.UUID = e.UUID
+	r.Title = e.Title
+	r.Body = e.Body
+	r.Created = e.Created
+	r.Read = e.Read
+}
+
+func transformPreferences(r *PreferencesResponse, e *PreferencesEntity) {
+	r.Timezone = e.Location.String()
+	r.Email = e.Email
+	r.Webhook = e.Webhook
+
+	r.Channels = make([]string, len(e.Channels))
+	for i, c := range e.Channels {
+		r.Channels[i] = c.String()
+	}
+}
+
+func transformReminder(r *ReminderResponse, e *ReminderEntity) {
+	r.UUID = e.UUID
+	r.Title = e.Title
+	r.Body = e.Body
+	r.Next = e.Next
+	r.Timezone = e.Next.Location().String()
+	r.Repeat = e.Repeat.String()
+}
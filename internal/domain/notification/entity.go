@@ -0,0 +1,308 @@
+package notification
+
+import (
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/period"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Notification struct {
+	uuid    uuid.UUID
+	user    uuid.UUID
+	title   string
+	body    string
+	created time.Time
+	read    bool
+}
+
+func New(user uuid.UUID, title, body string) (Notification, error) {
+	n := Notification{user: user, body: strings.TrimSpace(body)}
+
+	if err := set(&n.title, title, ProcessTitle); err != nil {
+		return Notification{}, xerrors.ErrNotificationCreation.New(err)
+	}
+
+	n.uuid = uuid.NewUUIDv7()
+	n.created = time.Now()
+	return n, nil
+}
+
+func (n *Notification) UUID() uuid.UUID    { return n.uuid }
+func (n *Notification) User() uuid.UUID    { return n.user }
+func (n *Notification) Title() string      { return n.title }
+func (n *Notification) Body() string       { return n.body }
+func (n *Notification) Created() time.Time { return n.created }
+func (n *Notification) Read() bool         { return n.read }
+
+func (n *Notification) SetRead(read bool) error {
+	n.read = read
+	return nil
+}
+
+type Channel int
+
+const (
+	_ Channel = iota
+	Inbox
+	Email
+	Webhook
+)
+
+var channelStrings = map[Channel]string{
+	Inbox:   "inbox",
+	Email:   "email",
+	Webhook: "webhook",
+}
+
+func ParseChannel(str string) (Channel, error) {
+	for c, name := range channelStrings {
+		if name == str {
+			return c, nil
+		}
+	}
+
+	return 0, xerrors.ErrBadChannel
+}
+
+func (c Channel) String() string {
+	return channelStrings[c]
+}
+
+type Preferences struct {
+	user     uuid.UUID
+	location *time.Location
+	channels []Channel
+	email    string
+	webhook  string
+}
+
+// DefaultPreferences are the preferences of users that never set
+// theirs, notifications only go to the inbox, in UTC.
+func DefaultPreferences(user uuid.UUID) Preferences {
+	return Preferences{
+		user:     user,
+		location: time.UTC,
+		channels: []Channel{Inbox},
+	}
+}
+
+func (p *Preferences) User() uuid.UUID          { return p.user }
+func (p *Preferences) Location() *time.Location { return p.location }
+func (p *Preferences) Channels() []Channel      { return slices.Clone(p.channels) }
+func (p *Preferences) Email() string            { return p.email }
+func (p *Preferences) Webhook() string          { return p.webhook }
+
+func (p *Preferences) SetTimezone(tz string) error   { return set(&p.location, tz, ProcessTimezone) }
+func (p *Preferences) SetChannels(cs []string) error { return set(&p.channels, cs, ProcessChannels) }
+func (p *Preferences) SetEmail(email string) error   { return set(&p.email, email, ProcessEmail) }
+func (p *Preferences) SetWebhook(hook string) error  { return set(&p.webhook, hook, ProcessWebhook) }
+
+// Validate checks whether every enabled channel has somewhere to
+// deliver to.
+func (p *Preferences) Validate() error {
+	var errs []error
+	for _, c := range p.channels {
+		if c == Email && p.email == "" || c == Webhook && p.webhook == "" {
+			errs = append(errs, xerrors.ErrChannelUnconfigured.New(c))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type Repeat int
+
+const (
+	_ Repeat = iota
+	Never
+	Daily
+	Weekly
+	Monthly
+)
+
+var repeatStrings = map[Repeat]string{
+	Never:   "never",
+	Daily:   "daily",
+	Weekly:  "weekly",
+	Monthly: "monthly",
+}
+
+func ParseRepeat(str string) (Repeat, error) {
+	for r, name := range repeatStrings {
+		if name == str {
+			return r, nil
+		}
+	}
+
+	return 0, xerrors.ErrBadRepeat
+}
+
+func (r Repeat) String() string {
+	return repeatStrings[r]
+}
+
+// After is the occurrence following t, of the series that started at
+// first, in the location of first, so the local time is kept across
+// daylight saving changes. Monthly occurrences keep the day of first,
+// clamped to the last day of shorter months, so a reminder on the 31st
+// falls on the 30th of April and back on the 31st of May.
+func (r Repeat) After(first, t time.Time) time.Time {
+	switch r {
+	case Daily:
+		return t.AddDate(0, 0, 1)
+	case Weekly:
+		return t.AddDate(0, 0, 7)
+	case Monthly:
+		months := (t.Year()-first.Year())*12 + int(t.Month()-first.Month()) + 1
+		d := period.DateOf(first).AddMonths(months)
+		return time.Date(d.Year(), d.Month(), d.Day(), first.Hour(), first.Minute(), first.Second(), first.Nanosecond(), first.Location())
+	}
+
+	return time.Time{}
+}
+
+type Reminder struct {
+	uuid   uuid.UUID
+	user   uuid.UUID
+	title  string
+	body   string
+	first  time.Time
+	next   time.Time
+	repeat Repeat
+}
+
+func NewReminder(user uuid.UUID, title, body string, at time.Time, repeat string) (Reminder, error) {
+	r := Reminder{user: user, body: strings.TrimSpace(body), first: at, next: at}
+
+	err := errors.Join(
+		set(&r.title, title, ProcessTitle),
+		set(&r.repeat, repeat, ProcessRepeat),
+	)
+	if err != nil {
+		return Reminder{}, xerrors.ErrReminderCreation.New(err)
+	}
+
+	r.uuid = uuid.NewUUIDv7()
+	return r, nil
+}
+
+func (r *Reminder) UUID() uuid.UUID { return r.uuid }
+func (r *Reminder) User() uuid.UUID { return r.user }
+func (r *Reminder) Title() string   { return r.title }
+func (r *Reminder) Body() string    { return r.body }
+func (r *Reminder) Next() time.Time { return r.next }
+func (r *Reminder) Repeat() Repeat  { return r.repeat }
+
+// Advance moves the reminder to its first occurrence after now,
+// occurrences missed in between are skipped. It reports false if the
+// reminder does not repeat.
+func (r *Reminder) Advance(now time.Time) bool {
+	if r.repeat == Never {
+		return false
+	}
+
+	for !r.next.After(now) {
+		r.next = r.repeat.After(r.first, r.next)
+	}
+
+	return true
+}
+
+func ProcessTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", xerrors.ErrTitleEmpty
+	}
+
+	return title, nil
+}
+
+func ProcessTimezone(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, xerrors.ErrBadTimezone.New(err)
+	}
+
+	return loc, nil
+}
+
+func ProcessChannels(names []string) ([]Channel, error) {
+	res := make([]Channel, 0, len(names))
+
+	for _, name := range names {
+		c, err := ParseChannel(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(res, c) {
+			res = append(res, c)
+		}
+	}
+
+	return res, nil
+}
+
+func ProcessEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return "", nil
+	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return "", xerrors.ErrBadEmail.New(err)
+	}
+
+	return addr.Address, nil
+}
+
+func ProcessWebhook(webhook string) (string, error) {
+	webhook = strings.TrimSpace(webhook)
+	if webhook == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(webhook)
+	if err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Hostname() == "" {
+		return "", xerrors.ErrBadWebhook
+	}
+
+	// hosts are checked again when delivering, once resolved
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return "", xerrors.ErrBadWebhook
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublic(addr) {
+		return "", xerrors.ErrBadWebhook
+	}
+
+	return u.String(), nil
+}
+
+func ProcessRepeat(repeat string) (Repeat, error) {
+	if repeat == "" {
+		return Never, nil
+	}
+
+	return ParseRepeat(repeat)
+}
+
+func set[T, R any](dst *R, src T, fn func(T) (R, error)) error {
+	val, err := fn(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = val
+	return nil
+}
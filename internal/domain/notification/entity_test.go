@@ -0,0 +1,125 @@
+package notification_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/alan-b-lima/prp/internal/domain/notification"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func TestMonthlyRepeat(t *testing.T) {
+	sp, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		first time.Time
+		next  []string
+	}{
+		{
+			time.Date(2026, time.January, 31, 9, 30, 0, 0, sp),
+			[]string{"2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31", "2026-06-30", "2026-07-31"},
+		},
+		{
+			time.Date(2028, time.February, 29, 9, 30, 0, 0, sp),
+			[]string{"2028-03-29", "2028-04-29", "2028-05-29"},
+		},
+		{
+			time.Date(2027, time.January, 29, 9, 30, 0, 0, sp),
+			[]string{"2027-02-28", "2027-03-29", "2027-04-29"},
+		},
+		{
+			time.Date(2025, time.December, 31, 9, 30, 0, 0, sp),
+			[]string{"2026-01-31", "2026-02-28", "2026-03-31"},
+		},
+	}
+
+	for _, test := range tests {
+		next := test.first
+		for _, want := range test.next {
+			next = Monthly.After(test.first, next)
+
+			if got := next.Format(time.DateOnly); got != want {
+				t.Errorf("%s: expected %s, got %s", test.first.Format(time.DateOnly), want, got)
+			}
+
+			if h, m, _ := next.Clock(); h != 9 || m != 30 || next.Location() != sp {
+				t.Errorf("%s: local time not kept, got %s", test.first.Format(time.DateOnly), next)
+			}
+		}
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	first := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+
+	r, err := NewReminder(uuid.NewUUIDv7(), "rent", "", first, "monthly")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// missed occurrences are skipped, the day of the month is not lost
+	// to the clamping on the way
+	now := time.Date(2026, time.April, 5, 0, 0, 0, 0, time.UTC)
+	if !r.Advance(now) {
+		t.Fatal("monthly reminder should advance")
+	}
+
+	if got := r.Next(); !got.Equal(time.Date(2026, time.April, 30, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 2026-04-30, got %s", got)
+	}
+
+	r.Advance(r.Next())
+	if got := r.Next(); !got.Equal(time.Date(2026, time.May, 31, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 2026-05-31, got %s", got)
+	}
+
+	once, err := NewReminder(uuid.NewUUIDv7(), "once", "", first, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if once.Advance(now) {
+		t.Error("non-repeating reminder should not advance")
+	}
+}
+
+func TestProcessWebhook(t *testing.T) {
+	good := []string{
+		"https://example.com/hook",
+		"http://93.184.216.34:8080/hook",
+		"https://[2606:4700::1111]/hook",
+	}
+
+	for _, webhook := range good {
+		if _, err := ProcessWebhook(webhook); err != nil {
+			t.Errorf("%s: %v", webhook, err)
+		}
+	}
+
+	bad := []string{
+		"ftp://example.com/hook",
+		"/hook",
+		"http:///hook",
+		"http://localhost:8080/hook",
+		"http://api.localhost/hook",
+		"http://127.0.0.1/hook",
+		"http://10.0.0.1/hook",
+		"http://192.168.0.10/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://0.0.0.0/hook",
+		"http://[::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+		"http://[fe80::1]/hook",
+		"http://[fd00::1]/hook",
+		"http://100.64.0.1/hook",
+	}
+
+	for _, webhook := range bad {
+		if _, err := ProcessWebhook(webhook); err == nil {
+			t.Errorf("%s should be rejected", webhook)
+		}
+	}
+}
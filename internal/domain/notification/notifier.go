@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"net/netip"
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+// Notifier delivers messages through a channel, implementations live
+// in the notifier package.
+type Notifier interface {
+	Notify(to Recipient, msg Message) error
+}
+
+type Recipient struct {
+	User    uuid.UUID
+	Email   string
+	Webhook string
+}
+
+type Message struct {
+	Title string
+	Body  string
+	Time  time.Time
+}
+
+// nonPublic are the ranges, beyond loopback, private and link-local
+// ones, that do not reach the public internet.
+var nonPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// IsPublic tells whether the address is reachable on the public
+// internet. Webhooks are only delivered to public addresses, so that
+// users cannot make the server reach its own network.
+func IsPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+
+	for _, p := range nonPublic {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
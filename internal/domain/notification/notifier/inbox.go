@@ -0,0 +1,17 @@
+package notifier
+
+import "github.com/alan-b-lima/prp/internal/domain/notification"
+
+// Inbox stores notifications in the repository, to be read in-app.
+type Inbox struct {
+	Repo notification.Creater
+}
+
+func NewInbox(notifications notification.Creater) *Inbox {
+	return &Inbox{Repo: notifications}
+}
+
+func (n *Inbox) Notify(to notification.Recipient, msg notification.Message) error {
+	_, err := n.Repo.Create(to.User, msg.Title, msg.Body)
+	return err
+}
@@ -0,0 +1,38 @@
+package notifier
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/domain/notification"
+)
+
+// SMTP sends notifications as plain text emails, meant to be pointed
+// at a local SMTP server, which relays or just collects them.
+type SMTP struct {
+	Addr string
+	From string
+}
+
+func NewSMTP(addr, from string) *SMTP {
+	return &SMTP{Addr: addr, From: from}
+}
+
+func (n *SMTP) Notify(to notification.Recipient, msg notification.Message) error {
+	if to.Email == "" {
+		return nil
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", n.From)
+	fmt.Fprintf(&b, "To: %s\r\n", to.Email)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Title))
+	fmt.Fprintf(&b, "Date: %s\r\n", msg.Time.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&b, "\r\n%s\r\n", msg.Body)
+
+	return smtp.SendMail(n.Addr, nil, n.From, []string{to.Email}, b.Bytes())
+}
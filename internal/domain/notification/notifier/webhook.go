@@ -0,0 +1,91 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/domain/notification"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+// Webhook posts notifications as JSON to the URL of the user.
+type Webhook struct {
+	Client *http.Client
+}
+
+// NewWebhook creates a webhook notifier that only connects to public
+// addresses. The check is made on the resolved address of every
+// connection, so it also holds for redirects and for host names that
+// resolve differently than when the webhook was set.
+func NewWebhook(timeout time.Duration) *Webhook {
+	dialer := &net.Dialer{Timeout: timeout, Control: publicOnly}
+
+	return &Webhook{Client: &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        16,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}}
+}
+
+var ErrNonPublicAddress = errors.New("webhook: address is not public")
+
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !notification.IsPublic(addr) {
+		return ErrNonPublicAddress
+	}
+
+	return nil
+}
+
+type payload struct {
+	User  uuid.UUID `json:"user"`
+	Title string    `json:"title"`
+	Body  string    `json:"body"`
+	Time  time.Time `json:"time"`
+}
+
+func (n *Webhook) Notify(to notification.Recipient, msg notification.Message) error {
+	if to.Webhook == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(payload{
+		User:  to.User,
+		Title: msg.Title,
+		Body:  msg.Body,
+		Time:  msg.Time,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := n.Client.Post(to.Webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook responded with %s", res.Status)
+	}
+
+	return nil
+}
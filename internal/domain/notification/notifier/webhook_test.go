@@ -0,0 +1,33 @@
+package notifier_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/domain/notification"
+	. "github.com/alan-b-lima/prp/internal/domain/notification/notifier"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func TestWebhookInternal(t *testing.T) {
+	var hit bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer srv.Close()
+
+	n := NewWebhook(time.Second)
+	to := notification.Recipient{User: uuid.NewUUIDv7(), Webhook: srv.URL}
+	msg := notification.Message{Title: "test", Time: time.Now()}
+
+	if err := n.Notify(to, msg); !errors.Is(err, ErrNonPublicAddress) {
+		t.Errorf("expected %v, got %v", ErrNonPublicAddress, err)
+	}
+
+	if hit {
+		t.Error("webhook reached a loopback address")
+	}
+}
@@ -0,0 +1,116 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Repository interface {
+	Lister
+	Getter
+	Creater
+	Patcher
+	Deleter
+	PreferencesGetter
+	PreferencesPatcher
+	ReminderLister
+	ReminderGetter
+	ReminderCreater
+	ReminderDeleter
+	ReminderAdvancer
+}
+
+type Lister interface {
+	List(user uuid.UUID, unread bool, offset, limit int) (ListEntity, error)
+}
+
+type Getter interface {
+	Get(uuid uuid.UUID) (Entity, error)
+}
+
+type Creater interface {
+	Create(user uuid.UUID, title, body string) (Entity, error)
+}
+
+type Patcher interface {
+	Patch(uuid uuid.UUID, read opt.Opt[bool]) (Entity, error)
+}
+
+type Deleter interface {
+	Delete(uuid uuid.UUID) error
+}
+
+// PreferencesGetter gets the preferences of the user, users without
+// preferences get [DefaultPreferences].
+type PreferencesGetter interface {
+	Preferences(user uuid.UUID) (PreferencesEntity, error)
+}
+
+type PreferencesPatcher interface {
+	PatchPreferences(user uuid.UUID, timezone opt.Opt[string], channels opt.Opt[[]string], email opt.Opt[string], webhook opt.Opt[string]) (PreferencesEntity, error)
+}
+
+type ReminderLister interface {
+	Reminders(user uuid.UUID, offset, limit int) (ReminderListEntity, error)
+}
+
+type ReminderGetter interface {
+	Reminder(uuid uuid.UUID) (ReminderEntity, error)
+}
+
+type ReminderCreater interface {
+	CreateReminder(user uuid.UUID, title, body string, at time.Time, repeat string) (ReminderEntity, error)
+}
+
+type ReminderDeleter interface {
+	DeleteReminder(uuid uuid.UUID) error
+}
+
+// ReminderAdvancer moves a fired reminder to its next occurrence,
+// reminders that do not repeat are deleted instead, in which case
+// false is reported.
+type ReminderAdvancer interface {
+	AdvanceReminder(uuid uuid.UUID, now time.Time) (ReminderEntity, bool, error)
+}
+
+type Entity struct {
+	UUID    uuid.UUID
+	User    uuid.UUID
+	Title   string
+	Body    string
+	Created time.Time
+	Read    bool
+}
+
+type ListEntity struct {
+	Offset       int
+	Length       int
+	Records      []Entity
+	TotalRecords int
+}
+
+type PreferencesEntity struct {
+	User     uuid.UUID
+	Location *time.Location
+	Channels []Channel
+	Email    string
+	Webhook  string
+}
+
+type ReminderEntity struct {
+	UUID   uuid.UUID
+	User   uuid.UUID
+	Title  string
+	Body   string
+	Next   time.Time
+	Repeat Repeat
+}
+
+type ReminderListEntity struct {
+	Offset       int
+	Length       int
+	Records      []ReminderEntity
+	TotalRecords int
+}
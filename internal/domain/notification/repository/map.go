@@ -0,0 +1,331 @@
+package notificationrepo
+
+import (
+	"cmp"
+	"sync"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/domain/notification"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Map struct {
+	uuidIndex     map[uuid.UUID]int
+	reminderIndex map[uuid.UUID]int
+	preferences   map[uuid.UUID]notification.Preferences
+
+	repo      []notification.Notification
+	reminders []notification.Reminder
+	mu        sync.RWMutex
+}
+
+func NewMap() notification.Repository {
+	repo := Map{
+		uuidIndex:     make(map[uuid.UUID]int),
+		reminderIndex: make(map[uuid.UUID]int),
+		preferences:   make(map[uuid.UUID]notification.Preferences),
+	}
+
+	return &repo
+}
+
+func (m *Map) List(user uuid.UUID, unread bool, offset, limit int) (notification.ListEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	// newest first, as in any inbox
+	var scoped []*notification.Notification
+	for i := len(m.repo) - 1; i >= 0; i-- {
+		n := &m.repo[i]
+		if n.User() == user && !(unread && n.Read()) {
+			scoped = append(scoped, n)
+		}
+	}
+
+	lo := clamp(0, offset, len(scoped))
+	hi := clamp(0, offset+limit, len(scoped))
+
+	if lo >= hi {
+		return notification.ListEntity{TotalRecords: len(scoped)}, nil
+	}
+
+	res := make([]notification.Entity, hi-lo)
+	for i, n := range scoped[lo:hi] {
+		transform(&res[i], n)
+	}
+
+	return notification.ListEntity{
+		Offset:       lo,
+		Length:       len(res),
+		Records:      res,
+		TotalRecords: len(scoped),
+	}, nil
+}
+
+func (m *Map) Get(uuid uuid.UUID) (notification.Entity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return notification.Entity{}, xerrors.ErrNotificationNotFound
+	}
+
+	var res notification.Entity
+	transform(&res, &m.repo[index])
+	return res, nil
+}
+
+func (m *Map) Create(user uuid.UUID, title, body string) (notification.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	n, err := notification.New(user, title, body)
+	if err != nil {
+		return notification.Entity{}, err
+	}
+
+	m.uuidIndex[n.UUID()] = len(m.repo)
+	m.repo = append(m.repo, n)
+
+	var res notification.Entity
+	transform(&res, &n)
+	return res, nil
+}
+
+func (m *Map) Patch(uuid uuid.UUID, read opt.Opt[bool]) (notification.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return notification.Entity{}, xerrors.ErrNotificationNotFound
+	}
+
+	n := m.repo[index]
+
+	if err := some_then(read, n.SetRead); err != nil {
+		return notification.Entity{}, err
+	}
+
+	m.repo[index] = n
+
+	var res notification.Entity
+	transform(&res, &n)
+	return res, nil
+}
+
+// Delete keeps the order of the remaining notifications, since the
+// inbox is listed by recency.
+func (m *Map) Delete(uuid uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return nil
+	}
+
+	delete(m.uuidIndex, uuid)
+
+	m.repo = append(m.repo[:index], m.repo[index+1:]...)
+	for i := index; i < len(m.repo); i++ {
+		m.uuidIndex[m.repo[i].UUID()] = i
+	}
+
+	return nil
+}
+
+func (m *Map) Preferences(user uuid.UUID) (notification.PreferencesEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	p, in := m.preferences[user]
+	if !in {
+		p = notification.DefaultPreferences(user)
+	}
+
+	var res notification.PreferencesEntity
+	transformPreferences(&res, &p)
+	return res, nil
+}
+
+func (m *Map) PatchPreferences(user uuid.UUID, timezone opt.Opt[string], channels opt.Opt[[]string], email opt.Opt[string], webhook opt.Opt[string]) (notification.PreferencesEntity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	p, in := m.preferences[user]
+	if !in {
+		p = notification.DefaultPreferences(user)
+	}
+
+	err := errors.Join(
+		some_then(timezone, p.SetTimezone),
+		some_then(channels, p.SetChannels),
+		some_then(email, p.SetEmail),
+		some_then(webhook, p.SetWebhook),
+	)
+	if err != nil {
+		return notification.PreferencesEntity{}, err
+	}
+
+	if err := p.Validate(); err != nil {
+		return notification.PreferencesEntity{}, err
+	}
+
+	m.preferences[user] = p
+
+	var res notification.PreferencesEntity
+	transformPreferences(&res, &p)
+	return res, nil
+}
+
+func (m *Map) Reminders(user uuid.UUID, offset, limit int) (notification.ReminderListEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var scoped []*notification.Reminder
+	for i := range m.reminders {
+		if m.reminders[i].User() == user {
+			scoped = append(scoped, &m.reminders[i])
+		}
+	}
+
+	lo := clamp(0, offset, len(scoped))
+	hi := clamp(0, offset+limit, len(scoped))
+
+	if lo >= hi {
+		return notification.ReminderListEntity{TotalRecords: len(scoped)}, nil
+	}
+
+	res := make([]notification.ReminderEntity, hi-lo)
+	for i, r := range scoped[lo:hi] {
+		transformReminder(&res[i], r)
+	}
+
+	return notification.ReminderListEntity{
+		Offset:       lo,
+		Length:       len(res),
+		Records:      res,
+		TotalRecords: len(scoped),
+	}, nil
+}
+
+func (m *Map) Reminder(uuid uuid.UUID) (notification.ReminderEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	index, in := m.reminderIndex[uuid]
+	if !in {
+		return notification.ReminderEntity{}, xerrors.ErrReminderNotFound
+	}
+
+	var res notification.ReminderEntity
+	transformReminder(&res, &m.reminders[index])
+	return res, nil
+}
+
+func (m *Map) CreateReminder(user uuid.UUID, title, body string, at time.Time, repeat string) (notification.ReminderEntity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	r, err := notification.NewReminder(user, title, body, at, repeat)
+	if err != nil {
+		return notification.ReminderEntity{}, err
+	}
+
+	m.reminderIndex[r.UUID()] = len(m.reminders)
+	m.reminders = append(m.reminders, r)
+
+	var res notification.ReminderEntity
+	transformReminder(&res, &r)
+	return res, nil
+}
+
+func (m *Map) DeleteReminder(uuid uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	m.deleteReminder(uuid)
+	return nil
+}
+
+func (m *Map) AdvanceReminder(uuid uuid.UUID, now time.Time) (notification.ReminderEntity, bool, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.reminderIndex[uuid]
+	if !in {
+		return notification.ReminderEntity{}, false, xerrors.ErrReminderNotFound
+	}
+
+	r := &m.reminders[index]
+
+	var res notification.ReminderEntity
+	if !r.Advance(now) {
+		transformReminder(&res, r)
+		m.deleteReminder(uuid)
+		return res, false, nil
+	}
+
+	transformReminder(&res, r)
+	return res, true, nil
+}
+
+func (m *Map) deleteReminder(uuid uuid.UUID) {
+	index, in := m.reminderIndex[uuid]
+	if !in {
+		return
+	}
+
+	delete(m.reminderIndex, uuid)
+
+	last := len(m.reminders) - 1
+	if index != last {
+		m.reminders[index] = m.reminders[last]
+		m.reminderIndex[m.reminders[index].UUID()] = index
+	}
+
+	m.reminders = m.reminders[:last]
+}
+
+func some_then[T any](src opt.Opt[T], fn func(T) error) error {
+	if !src.Some {
+		return nil
+	}
+
+	return fn(src.Val)
+}
+
+func transform(r *notification.Entity, n *notification.Notification) {
+	r.UUID = n.UUID()
+	r.User = n.User()
+	r.Title = n.Title()
+	r.Body = n.Body()
+	r.Created = n.Created()
+	r.Read = n.Read()
+}
+
+func transformPreferences(r *notification.PreferencesEntity, p *notification.Preferences) {
+	r.User = p.User()
+	r.Location = p.Location()
+	r.Channels = p.Channels()
+	r.Email = p.Email()
+	r.Webhook = p.Webhook()
+}
+
+func transformReminder(r *notification.ReminderEntity, rm *notification.Reminder) {
+	r.UUID = rm.UUID()
+	r.User = rm.User()
+	r.Title = rm.Title()
+	r.Body = rm.Body()
+	r.Next = rm.Next()
+	r.Repeat = rm.Repeat()
+}
+
+func clamp[T cmp.Ordered](mn, val, mx T) T {
+	return min(max(mn, val), mx)
+}
@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/heap"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+// Scheduler calls fire for each scheduled reminder once its time
+// comes. Entries are never removed, fire must ignore reminders that
+// were deleted or rescheduled in the meantime.
+type Scheduler struct {
+	heap   heap.Heap[entry]
+	new    chan entry
+	cancel chan struct{}
+	fire   func(reminder uuid.UUID, at time.Time)
+}
+
+func NewScheduler(fire func(reminder uuid.UUID, at time.Time)) *Scheduler {
+	s := Scheduler{
+		new:    make(chan entry, 32),
+		cancel: make(chan struct{}, 1),
+		fire:   fire,
+	}
+
+	go s.run()
+
+	return &s
+}
+
+func (s *Scheduler) Schedule(reminder uuid.UUID, at time.Time) {
+	s.new <- entry{reminder, at}
+}
+
+func (s *Scheduler) Close() {
+	s.cancel <- struct{}{}
+}
+
+func (s *Scheduler) run() {
+	for {
+		var after <-chan time.Time
+		if s.heap.Len() > 0 {
+			delay := time.Until(s.heap.Peek().at)
+			after = time.After(delay)
+		}
+
+		select {
+		case <-s.cancel:
+			return
+
+		case e := <-s.new:
+			s.heap.Push(e)
+
+		case <-after:
+			e := s.heap.Pop()
+
+			// fire may schedule the next occurrence, which would
+			// block on a full channel if called from this goroutine
+			go s.fire(e.reminder, e.at)
+		}
+	}
+}
+
+type entry struct {
+	reminder uuid.UUID
+	at       time.Time
+}
+
+func (o0 entry) Less(o1 entry) bool { return o0.at.Before(o1.at) }
@@ -0,0 +1,159 @@
+package notification
+
+import (
+	"log"
+	"time"
+
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Service struct {
+	Repo      Repository
+	Notifiers map[Channel]Notifier
+	Scheduler *Scheduler
+}
+
+func NewService(notifications Repository, notifiers map[Channel]Notifier) *Service {
+	s := Service{
+		Repo:      notifications,
+		Notifiers: notifiers,
+	}
+
+	s.Scheduler = NewScheduler(s.fire)
+	return &s
+}
+
+var PermGeneral = auth.Permission(auth.Admin, auth.User)
+
+func (s *Service) List(ctx auth.Context, req ListRequest) (ListResponse, error) {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return ListResponse{}, xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	req.User = ctx.User()
+	return List(s.Repo, req)
+}
+
+func (s *Service) Get(ctx auth.Context, req GetRequest) (Response, error) {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return Response{}, err
+	}
+
+	return Get(s.Repo, req)
+}
+
+func (s *Service) Patch(ctx auth.Context, req PatchRequest) (Response, error) {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return Response{}, err
+	}
+
+	return Patch(s.Repo, req)
+}
+
+func (s *Service) Delete(ctx auth.Context, req DeleteRequest) error {
+	if err := s.authorize(ctx, req.UUID); err != nil {
+		return err
+	}
+
+	return Delete(s.Repo, req)
+}
+
+func (s *Service) Test(ctx auth.Context, req TestRequest) error {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	req.User = ctx.User()
+	return Test(s.Repo, s.Notifiers, req)
+}
+
+func (s *Service) Preferences(ctx auth.Context, req PreferencesRequest) (PreferencesResponse, error) {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return PreferencesResponse{}, xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	req.User = ctx.User()
+	return GetPreferences(s.Repo, req)
+}
+
+func (s *Service) PatchPreferences(ctx auth.Context, req PatchPreferencesRequest) (PreferencesResponse, error) {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return PreferencesResponse{}, xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	req.User = ctx.User()
+	return PatchPreferences(s.Repo, req)
+}
+
+func (s *Service) Reminders(ctx auth.Context, req RemindersRequest) (ReminderListResponse, error) {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return ReminderListResponse{}, xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	req.User = ctx.User()
+	return Reminders(s.Repo, req)
+}
+
+func (s *Service) CreateReminder(ctx auth.Context, req CreateReminderRequest) (ReminderResponse, error) {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return ReminderResponse{}, xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	req.User = ctx.User()
+	res, err := CreateReminder(s.Repo, req)
+	if err != nil {
+		return ReminderResponse{}, err
+	}
+
+	s.Scheduler.Schedule(res.UUID, res.Next)
+	return res, nil
+}
+
+func (s *Service) DeleteReminder(ctx auth.Context, req DeleteReminderRequest) error {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	res, err := s.Repo.Reminder(req.UUID)
+	if err != nil {
+		return err
+	}
+
+	if res.User != ctx.User() {
+		return xerrors.ErrReminderNotFound
+	}
+
+	return DeleteReminder(s.Repo, req)
+}
+
+// authorize checks whether the notification belongs to the logged
+// user, notifications of others are reported as not found.
+func (s *Service) authorize(ctx auth.Context, notification uuid.UUID) error {
+	if l, c := ctx.Level(), PermGeneral; !c.Authorize(l) {
+		return xerrors.ErrUnauthorizedUser.New(l, c)
+	}
+
+	res, err := s.Repo.Get(notification)
+	if err != nil {
+		return err
+	}
+
+	if res.User != ctx.User() {
+		return xerrors.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+func (s *Service) fire(reminder uuid.UUID, at time.Time) {
+	next, more, err := Fire(s.Repo, s.Notifiers, reminder, at)
+	if err != nil {
+		log.Printf("reminder %v: %v\n", reminder, err)
+	}
+
+	if more {
+		s.Scheduler.Schedule(reminder, next)
+	}
+}
@@ -0,0 +1,108 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+// LocalTime is the layout of reminder times, a date and time of day
+// without offset, taken in the timezone of the user.
+const LocalTime = "2006-01-02T15:04"
+
+type (
+	ListRequest struct {
+		User   uuid.UUID `json:"-"`
+		Unread bool      `json:"-"`
+		Offset int       `json:"-"`
+		Limit  int       `json:"-"`
+	}
+
+	GetRequest struct {
+		UUID uuid.UUID `json:"-"`
+	}
+
+	PatchRequest struct {
+		UUID uuid.UUID     `json:"-"`
+		Read opt.Opt[bool] `json:"read"`
+	}
+
+	DeleteRequest struct {
+		UUID uuid.UUID `json:"-"`
+	}
+
+	TestRequest struct {
+		User uuid.UUID `json:"-"`
+	}
+
+	PreferencesRequest struct {
+		User uuid.UUID `json:"-"`
+	}
+
+	PatchPreferencesRequest struct {
+		User     uuid.UUID         `json:"-"`
+		Timezone opt.Opt[string]   `json:"timezone"`
+		Channels opt.Opt[[]string] `json:"channels"`
+		Email    opt.Opt[string]   `json:"email"`
+		Webhook  opt.Opt[string]   `json:"webhook"`
+	}
+
+	RemindersRequest struct {
+		User   uuid.UUID `json:"-"`
+		Offset int       `json:"-"`
+		Limit  int       `json:"-"`
+	}
+
+	CreateReminderRequest struct {
+		User   uuid.UUID `json:"-"`
+		Title  string    `json:"title"`
+		Body   string    `json:"body"`
+		At     string    `json:"at"`
+		Repeat string    `json:"repeat"`
+	}
+
+	DeleteReminderRequest struct {
+		UUID uuid.UUID `json:"-"`
+	}
+)
+
+type (
+	ListResponse struct {
+		Offset       int        `json:"offset"`
+		Length       int        `json:"length"`
+		Records      []Response `json:"records"`
+		TotalRecords int        `json:"total_records"`
+	}
+
+	Response struct {
+		UUID    uuid.UUID `json:"uuid"`
+		Title   string    `json:"title"`
+		Body    string    `json:"body"`
+		Created time.Time `json:"created"`
+		Read    bool      `json:"read"`
+	}
+
+	PreferencesResponse struct {
+		Timezone string   `json:"timezone"`
+		Channels []string `json:"channels"`
+		Email    string   `json:"email"`
+		Webhook  string   `json:"webhook"`
+	}
+
+	ReminderListResponse struct {
+		Offset       int                `json:"offset"`
+		Length       int                `json:"length"`
+		Records      []ReminderResponse `json:"records"`
+		TotalRecords int                `json:"total_records"`
+	}
+
+	ReminderResponse struct {
+		UUID     uuid.UUID `json:"uuid"`
+		Title    string    `json:"title"`
+		Body     string    `json:"body"`
+		Next     time.Time `json:"next"`
+		Timezone string    `json:"timezone"`
+		Repeat   string    `json:"repeat"`
+	}
+)
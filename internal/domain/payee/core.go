@@ -0,0 +1,79 @@
+package payee
+
+func List(payees Lister, req ListRequest) (ListResponse, error) {
+	res, err := payees.List(req.Book, req.Offset, req.Limit)
+	if err != nil {
+		return ListResponse{}, err
+	}
+
+	ares := ListResponse{
+		Offset:       res.Offset,
+		Length:       res.Length,
+		Records:      make([]Response, res.Length),
+		TotalRecords: res.TotalRecords,
+	}
+	for i := 0; i < res.Length; i++ {
+		transform(&ares.Records[i], &res.Records[i])
+	}
+
+	return ares, nil
+}
+
+func Get(payees Getter, req GetRequest) (Response, error) {
+	res, err := payees.Get(req.UUID)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Create(payees Creater, req CreateRequest) (Response, error) {
+	res, err := payees.Create(req.Book, req.Name, req.Aliases, req.Document)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Patch(payees Patcher, req PatchRequest) (Response, error) {
+	res, err := payees.Patch(req.UUID, req.Name, req.Aliases, req.Document)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Delete(payees Deleter, req DeleteRequest) error {
+	return payees.Delete(req.UUID)
+}
+
+func Merge(payees Merger, req MergeRequest) (Response, error) {
+	res, err := payees.Merge(req.UUID, req.From)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func transform(r *Response, e *Entity) {
+	r.UUID = e.UUID
+	r.Book = e.Book
+	r.Name = e.Name
+	r.Aliases = e.Aliases
+	if r.Aliases == nil {
+		r.Aliases = []string{}
+	}
+	r.Document = e.Document
+}
@@ -0,0 +1,104 @@
+package payee
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/document"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Payee struct {
+	uuid     uuid.UUID
+	book     uuid.UUID
+	name     string
+	aliases  []string
+	document document.Document
+}
+
+func New(book uuid.UUID, name string, aliases []string, doc string) (Payee, error) {
+	p := Payee{book: book}
+
+	err := errors.Join(
+		p.SetName(name),
+		p.SetAliases(aliases),
+		p.SetDocument(doc),
+	)
+	if err != nil {
+		return Payee{}, xerrors.ErrPayeeCreation.New(err)
+	}
+
+	p.uuid = uuid.NewUUIDv7()
+	return p, nil
+}
+
+func (p *Payee) UUID() uuid.UUID             { return p.uuid }
+func (p *Payee) Book() uuid.UUID             { return p.book }
+func (p *Payee) Name() string                { return p.name }
+func (p *Payee) Aliases() []string           { return slices.Clone(p.aliases) }
+func (p *Payee) Document() document.Document { return p.document }
+
+func (p *Payee) SetName(name string) error         { return set(&p.name, name, ProcessName) }
+func (p *Payee) SetAliases(aliases []string) error { return set(&p.aliases, aliases, ProcessAliases) }
+func (p *Payee) SetDocument(doc string) error      { return set(&p.document, doc, ProcessDocument) }
+
+// Absorb takes the name and aliases of other as aliases of p, the
+// document of other is only taken if p lacks one.
+func (p *Payee) Absorb(other *Payee) {
+	for _, alias := range append([]string{other.name}, other.aliases...) {
+		if alias != p.name && !slices.Contains(p.aliases, alias) {
+			p.aliases = append(p.aliases, alias)
+		}
+	}
+
+	if p.document.IsZero() {
+		p.document = other.document
+	}
+}
+
+func ProcessName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", xerrors.ErrNameEmpty
+	}
+
+	return name, nil
+}
+
+func ProcessAliases(aliases []string) ([]string, error) {
+	res := make([]string, 0, len(aliases))
+
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			return nil, xerrors.ErrAliasEmpty
+		}
+
+		if !slices.Contains(res, alias) {
+			res = append(res, alias)
+		}
+	}
+
+	return res, nil
+}
+
+func ProcessDocument(doc string) (document.Document, error) {
+	d, err := document.Parse(doc)
+	if err != nil {
+		return document.Document{}, xerrors.ErrBadDocument.New(err)
+	}
+
+	return d, nil
+}
+
+func set[T, R any](dst *R, src T, fn func(T) (R, error)) error {
+	val, err := fn(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = val
+	return nil
+}
@@ -0,0 +1,63 @@
+package payee
+
+import (
+	"github.com/alan-b-lima/prp/pkg/document"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Repository interface {
+	Lister
+	Counter
+	Getter
+	Creater
+	Patcher
+	Deleter
+	Merger
+}
+
+type Lister interface {
+	List(book uuid.UUID, offset, limit int) (ListEntity, error)
+}
+
+// Counter counts the payees of a book.
+type Counter interface {
+	Count(book uuid.UUID) (int, error)
+}
+
+type Getter interface {
+	Get(uuid uuid.UUID) (Entity, error)
+}
+
+type Creater interface {
+	Create(book uuid.UUID, name string, aliases []string, document string) (Entity, error)
+}
+
+type Patcher interface {
+	Patch(uuid uuid.UUID, name opt.Opt[string], aliases opt.Opt[[]string], document opt.Opt[string]) (Entity, error)
+}
+
+type Deleter interface {
+	Delete(uuid uuid.UUID) error
+}
+
+type Merger interface {
+	Merge(into uuid.UUID, from []uuid.UUID) (Entity, error)
+}
+
+type Entity struct {
+	UUID     uuid.UUID
+	Book     uuid.UUID
+	Name     string
+	Aliases  []string
+	Document document.Document
+}
+
+func (e Entity) InBook() uuid.UUID { return e.Book }
+
+type ListEntity struct {
+	Offset       int
+	Length       int
+	Records      []Entity
+	TotalRecords int
+}
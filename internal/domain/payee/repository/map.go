@@ -0,0 +1,221 @@
+package payeerepo
+
+import (
+	"cmp"
+	"sync"
+
+	"github.com/alan-b-lima/prp/internal/domain/payee"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Map struct {
+	uuidIndex map[uuid.UUID]int
+
+	repo []payee.Payee
+	mu   sync.RWMutex
+}
+
+func NewMap() payee.Repository {
+	repo := Map{
+		uuidIndex: make(map[uuid.UUID]int),
+	}
+
+	return &repo
+}
+
+func (m *Map) List(book uuid.UUID, offset, limit int) (payee.ListEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var scoped []*payee.Payee
+	for i := range m.repo {
+		if m.repo[i].Book() == book {
+			scoped = append(scoped, &m.repo[i])
+		}
+	}
+
+	lo := clamp(0, offset, len(scoped))
+	hi := clamp(0, offset+limit, len(scoped))
+
+	if lo >= hi {
+		return payee.ListEntity{TotalRecords: len(scoped)}, nil
+	}
+
+	res := make([]payee.Entity, hi-lo)
+	for i, p := range scoped[lo:hi] {
+		transform(&res[i], p)
+	}
+
+	return payee.ListEntity{
+		Offset:       lo,
+		Length:       len(res),
+		Records:      res,
+		TotalRecords: len(scoped),
+	}, nil
+}
+
+func (m *Map) Count(book uuid.UUID) (int, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var count int
+	for i := range m.repo {
+		if m.repo[i].Book() == book {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (m *Map) Get(uuid uuid.UUID) (payee.Entity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return payee.Entity{}, xerrors.ErrPayeeNotFound
+	}
+
+	var res payee.Entity
+	transform(&res, &m.repo[index])
+	return res, nil
+}
+
+func (m *Map) Create(book uuid.UUID, name string, aliases []string, document string) (payee.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	p, err := payee.New(book, name, aliases, document)
+	if err != nil {
+		return payee.Entity{}, err
+	}
+
+	m.uuidIndex[p.UUID()] = len(m.repo)
+	m.repo = append(m.repo, p)
+
+	var res payee.Entity
+	transform(&res, &p)
+	return res, nil
+}
+
+func (m *Map) Patch(uuid uuid.UUID, name opt.Opt[string], aliases opt.Opt[[]string], document opt.Opt[string]) (payee.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return payee.Entity{}, xerrors.ErrPayeeNotFound
+	}
+
+	p := m.repo[index]
+
+	err := errors.Join(
+		some_then(name, p.SetName),
+		some_then(aliases, p.SetAliases),
+		some_then(document, p.SetDocument),
+	)
+	if err != nil {
+		return payee.Entity{}, err
+	}
+
+	m.repo[index] = p
+
+	var res payee.Entity
+	transform(&res, &p)
+	return res, nil
+}
+
+func (m *Map) Delete(uuid uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	m.delete(uuid)
+	return nil
+}
+
+func (m *Map) Merge(into uuid.UUID, from []uuid.UUID) (payee.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[into]
+	if !in {
+		return payee.Entity{}, xerrors.ErrPayeeNotFound
+	}
+
+	p := m.repo[index]
+
+	// validate every source before touching anything, so a failed
+	// merge leaves the repository as it was
+	for _, uuid := range from {
+		if uuid == into {
+			return payee.Entity{}, xerrors.ErrPayeeMergeSelf
+		}
+
+		index, in := m.uuidIndex[uuid]
+		if !in {
+			return payee.Entity{}, xerrors.ErrPayeeNotFound
+		}
+
+		if m.repo[index].Book() != p.Book() {
+			return payee.Entity{}, xerrors.ErrPayeeMergeBook
+		}
+	}
+
+	for _, uuid := range from {
+		index, in := m.uuidIndex[uuid]
+		if !in {
+			// repeated in from, already merged
+			continue
+		}
+
+		p.Absorb(&m.repo[index])
+		m.delete(uuid)
+	}
+
+	m.repo[m.uuidIndex[into]] = p
+
+	var res payee.Entity
+	transform(&res, &p)
+	return res, nil
+}
+
+func (m *Map) delete(uuid uuid.UUID) {
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return
+	}
+
+	delete(m.uuidIndex, uuid)
+
+	last := len(m.repo) - 1
+	if index != last {
+		m.repo[index] = m.repo[last]
+		m.uuidIndex[m.repo[index].UUID()] = index
+	}
+
+	m.repo = m.repo[:last]
+}
+
+func some_then[T any](src opt.Opt[T], fn func(T) error) error {
+	if !src.Some {
+		return nil
+	}
+
+	return fn(src.Val)
+}
+
+func transform(r *payee.Entity, p *payee.Payee) {
+	r.UUID = p.UUID()
+	r.Book = p.Book()
+	r.Name = p.Name()
+	r.Aliases = p.Aliases()
+	r.Document = p.Document()
+}
+
+func clamp[T cmp.Ordered](mn, val, mx T) T {
+	return min(max(mn, val), mx)
+}
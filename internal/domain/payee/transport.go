@@ -0,0 +1,63 @@
+package payee
+
+import (
+	"github.com/alan-b-lima/prp/pkg/document"
+	"github.com/alan-b-lima/prp/pkg/opt"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type (
+	ListRequest struct {
+		Book   uuid.UUID `json:"-"`
+		Offset int       `json:"-"`
+		Limit  int       `json:"-"`
+	}
+
+	GetRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	CreateRequest struct {
+		Book     uuid.UUID `json:"-"`
+		Name     string    `json:"name"`
+		Aliases  []string  `json:"aliases"`
+		Document string    `json:"document"`
+	}
+
+	PatchRequest struct {
+		Book     uuid.UUID         `json:"-"`
+		UUID     uuid.UUID         `json:"-"`
+		Name     opt.Opt[string]   `json:"name"`
+		Aliases  opt.Opt[[]string] `json:"aliases"`
+		Document opt.Opt[string]   `json:"document"`
+	}
+
+	DeleteRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	MergeRequest struct {
+		Book uuid.UUID   `json:"-"`
+		UUID uuid.UUID   `json:"-"`
+		From []uuid.UUID `json:"from"`
+	}
+)
+
+type (
+	ListResponse struct {
+		Offset       int        `json:"offset"`
+		Length       int        `json:"length"`
+		Records      []Response `json:"records"`
+		TotalRecords int        `json:"total_records"`
+	}
+
+	Response struct {
+		UUID     uuid.UUID         `json:"uuid"`
+		Book     uuid.UUID         `json:"book"`
+		Name     string            `json:"name"`
+		Aliases  []string          `json:"aliases"`
+		Document document.Document `json:"document"`
+	}
+)
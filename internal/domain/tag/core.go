@@ -0,0 +1,75 @@
+package tag
+
+func List(tags Lister, req ListRequest) (ListResponse, error) {
+	res, err := tags.List(req.Book, req.Offset, req.Limit)
+	if err != nil {
+		return ListResponse{}, err
+	}
+
+	ares := ListResponse{
+		Offset:       res.Offset,
+		Length:       res.Length,
+		Records:      make([]Response, res.Length),
+		TotalRecords: res.TotalRecords,
+	}
+	for i := 0; i < res.Length; i++ {
+		transform(&ares.Records[i], &res.Records[i])
+	}
+
+	return ares, nil
+}
+
+func Get(tags Getter, req GetRequest) (Response, error) {
+	res, err := tags.Get(req.UUID)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Create(tags Creater, req CreateRequest) (Response, error) {
+	res, err := tags.Create(req.Book, req.Name)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Rename(tags Renamer, req RenameRequest) (Response, error) {
+	res, err := tags.Rename(req.UUID, req.Name)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func Delete(tags Deleter, req DeleteRequest) error {
+	return tags.Delete(req.UUID)
+}
+
+func Merge(tags Merger, req MergeRequest) (Response, error) {
+	res, err := tags.Merge(req.UUID, req.From)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var ares Response
+	transform(&ares, &res)
+	return ares, nil
+}
+
+func transform(r *Response, e *Entity) {
+	r.UUID = e.UUID
+	r.Book = e.Book
+	r.Name = e.Name
+	r.Parent = Parent(e.Name)
+}
@@ -0,0 +1,201 @@
+package tag_test
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/alan-b-lima/prp/internal/domain/tag"
+	tagrepo "github.com/alan-b-lima/prp/internal/domain/tag/repository"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+func setup(t *testing.T, names ...string) (Repository, uuid.UUID, map[string]uuid.UUID) {
+	t.Helper()
+
+	tags := tagrepo.NewMap()
+	book := uuid.NewUUIDv7()
+
+	ids := make(map[string]uuid.UUID)
+	for _, name := range names {
+		res, err := Create(tags, CreateRequest{Book: book, Name: name})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		ids[name] = res.UUID
+	}
+
+	return tags, book, ids
+}
+
+func names(t *testing.T, tags Repository, book uuid.UUID) []string {
+	t.Helper()
+
+	res, err := List(tags, ListRequest{Book: book, Limit: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, r := range res.Records {
+		names = append(names, r.Name)
+	}
+
+	return names
+}
+
+func title(err error) string {
+	if err, ok := errors.AsType[*errors.Error](err); ok {
+		return err.Title
+	}
+
+	return ""
+}
+
+func TestCreate(t *testing.T) {
+	tags, book, _ := setup(t, "travel-x", "travel/uber", " travel / 99 ", "travel", "food")
+
+	want := []string{"food", "travel", "travel/99", "travel/uber", "travel-x"}
+	if got := names(t, tags, book); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	res, err := Create(tags, CreateRequest{Book: book, Name: "a/b/c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Parent != "a/b" {
+		t.Errorf("expected parent a/b, got %q", res.Parent)
+	}
+
+	for _, name := range []string{"", "/", "a/", "/a", "a//b", " "} {
+		if _, err := Create(tags, CreateRequest{Book: book, Name: name}); err == nil {
+			t.Errorf("%q should not be a valid tag name", name)
+		}
+	}
+
+	if _, err := Create(tags, CreateRequest{Book: book, Name: "travel/uber"}); title(err) != "tag-name-taken" {
+		t.Errorf("expected the name to be taken, got %v", err)
+	}
+
+	if _, err := Create(tags, CreateRequest{Book: uuid.NewUUIDv7(), Name: "travel/uber"}); err != nil {
+		t.Errorf("names should only be unique within a book, got %v", err)
+	}
+}
+
+func TestRename(t *testing.T) {
+	tags, book, ids := setup(t, "travel", "travel/uber", "travel/uber/pool", "travel-x", "trips/99")
+
+	res, err := Rename(tags, RenameRequest{Book: book, UUID: ids["travel"], Name: "trips"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Name != "trips" {
+		t.Errorf("expected trips, got %s", res.Name)
+	}
+
+	want := []string{"travel-x", "trips", "trips/99", "trips/uber", "trips/uber/pool"}
+	if got := names(t, tags, book); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// trips/uber exists, so trips/99 cannot be renamed to it, and
+	// trips cannot be moved under its own descendants
+	if _, err := Rename(tags, RenameRequest{Book: book, UUID: ids["trips/99"], Name: "trips/uber"}); title(err) != "tag-name-taken" {
+		t.Errorf("expected the name to be taken, got %v", err)
+	}
+
+	if _, err := Rename(tags, RenameRequest{Book: book, UUID: ids["travel"], Name: "trips/uber/x"}); err != xerrors.ErrTagUnderItself {
+		t.Errorf("expected %v, got %v", xerrors.ErrTagUnderItself, err)
+	}
+
+	if got := names(t, tags, book); !slices.Equal(got, want) {
+		t.Errorf("failed renames should change nothing, expected %v, got %v", want, got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tags, book, ids := setup(t, "uber", "uber/pool", "uber/eats", "UBER *TRIP", "UBER *TRIP/pool", "UBER *TRIP/black", "transport/uber")
+
+	res, err := Merge(tags, MergeRequest{Book: book, UUID: ids["uber"], From: []uuid.UUID{ids["UBER *TRIP"], ids["UBER *TRIP/pool"]}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.UUID != ids["uber"] || res.Name != "uber" {
+		t.Errorf("unexpected target %+v", res)
+	}
+
+	// pool was already under uber, so it was folded into it, black was
+	// moved over
+	want := []string{"transport/uber", "uber", "uber/black", "uber/eats", "uber/pool"}
+	if got := names(t, tags, book); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if _, err := Get(tags, GetRequest{Book: book, UUID: ids["uber/pool"]}); err != nil {
+		t.Errorf("the existing child should be kept, got %v", err)
+	}
+
+	if _, err := Get(tags, GetRequest{Book: book, UUID: ids["UBER *TRIP/pool"]}); err != xerrors.ErrTagNotFound {
+		t.Errorf("the folded child should be gone, got %v", err)
+	}
+
+	bad := []struct {
+		into uuid.UUID
+		from uuid.UUID
+		err  error
+	}{
+		{ids["uber"], ids["uber"], xerrors.ErrTagMergeSelf},
+		{ids["uber/pool"], ids["uber"], xerrors.ErrTagMergeSelf},
+		{ids["uber"], uuid.NewUUIDv7(), xerrors.ErrTagNotFound},
+	}
+
+	for _, test := range bad {
+		if _, err := Merge(tags, MergeRequest{Book: book, UUID: test.into, From: []uuid.UUID{test.from}}); err != test.err {
+			t.Errorf("expected %v, got %v", test.err, err)
+		}
+	}
+
+	if got := names(t, tags, book); !slices.Equal(got, want) {
+		t.Errorf("failed merges should change nothing, expected %v, got %v", want, got)
+	}
+
+	// merging into a child moves the rest of the parent under it
+	if _, err := Merge(tags, MergeRequest{Book: book, UUID: ids["transport/uber"], From: []uuid.UUID{ids["uber"]}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want = []string{"transport/uber", "transport/uber/black", "transport/uber/eats", "transport/uber/pool"}
+	if got := names(t, tags, book); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMergeIntoAncestor(t *testing.T) {
+	tags, book, ids := setup(t, "travel", "travel/uber", "travel/uber/pool", "travel/uber/uber", "travel/pool", "travel/hotel")
+
+	if _, err := Merge(tags, MergeRequest{Book: book, UUID: ids["travel"], From: []uuid.UUID{ids["travel/uber"], ids["travel/uber/pool"]}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the merged tag is folded into its ancestor, its children are
+	// moved up, folding into those already there
+	want := []string{"travel", "travel/hotel", "travel/pool", "travel/uber"}
+	if got := names(t, tags, book); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if _, err := Get(tags, GetRequest{Book: book, UUID: ids["travel/uber"]}); err != xerrors.ErrTagNotFound {
+		t.Errorf("the merged tag should be gone, got %v", err)
+	}
+
+	res, err := Get(tags, GetRequest{Book: book, UUID: ids["travel/uber/uber"]})
+	if err != nil || res.Name != "travel/uber" {
+		t.Errorf("the grandchild should have been moved up, got %+v, %v", res, err)
+	}
+}
@@ -0,0 +1,91 @@
+package tag
+
+import (
+	"strings"
+
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+// Separator separates the segments of tag names, travel/uber is the
+// tag uber under travel. Parents need not exist as tags themselves.
+const Separator = "/"
+
+type Tag struct {
+	uuid uuid.UUID
+	book uuid.UUID
+	name string
+}
+
+func New(book uuid.UUID, name string) (Tag, error) {
+	t := Tag{book: book}
+
+	err := errors.Join(
+		t.SetName(name),
+	)
+	if err != nil {
+		return Tag{}, xerrors.ErrTagCreation.New(err)
+	}
+
+	t.uuid = uuid.NewUUIDv7()
+	return t, nil
+}
+
+func (t *Tag) UUID() uuid.UUID { return t.uuid }
+func (t *Tag) Book() uuid.UUID { return t.book }
+func (t *Tag) Name() string    { return t.name }
+
+func (t *Tag) SetName(name string) error { return set(&t.name, name, ProcessName) }
+
+// Parent is the name of the tag the named one is under, empty for
+// top-level tags.
+func Parent(name string) string {
+	i := strings.LastIndex(name, Separator)
+	if i < 0 {
+		return ""
+	}
+
+	return name[:i]
+}
+
+// Within tells whether the named tag is the ancestor or one of its
+// descendants.
+func Within(name, ancestor string) bool {
+	return name == ancestor || strings.HasPrefix(name, ancestor+Separator)
+}
+
+// Reparent moves the name from under one tag to under another, it
+// reports false, leaving the name as is, if the name is neither from
+// nor one of its descendants.
+func Reparent(name, from, to string) (string, bool) {
+	if !Within(name, from) {
+		return name, false
+	}
+
+	return to + name[len(from):], true
+}
+
+func ProcessName(name string) (string, error) {
+	segments := strings.Split(name, Separator)
+	for i, segment := range segments {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			return "", xerrors.ErrBadTagName
+		}
+
+		segments[i] = segment
+	}
+
+	return strings.Join(segments, Separator), nil
+}
+
+func set[T, R any](dst *R, src T, fn func(T) (R, error)) error {
+	val, err := fn(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = val
+	return nil
+}
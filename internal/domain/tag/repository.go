@@ -0,0 +1,64 @@
+package tag
+
+import "github.com/alan-b-lima/prp/pkg/uuid"
+
+type Repository interface {
+	Lister
+	Counter
+	Getter
+	Creater
+	Renamer
+	Deleter
+	Merger
+}
+
+// Lister lists the tags of a book ordered by name, so that tags come
+// right after their parents.
+type Lister interface {
+	List(book uuid.UUID, offset, limit int) (ListEntity, error)
+}
+
+// Counter counts the tags of a book.
+type Counter interface {
+	Count(book uuid.UUID) (int, error)
+}
+
+type Getter interface {
+	Get(uuid uuid.UUID) (Entity, error)
+}
+
+type Creater interface {
+	Create(book uuid.UUID, name string) (Entity, error)
+}
+
+// Renamer renames a tag along with its descendants, so that renaming
+// travel to trips turns travel/uber into trips/uber.
+type Renamer interface {
+	Rename(uuid uuid.UUID, name string) (Entity, error)
+}
+
+type Deleter interface {
+	Delete(uuid uuid.UUID) error
+}
+
+// Merger folds tags into another, the descendants of the folded tags
+// are moved under the target, and folded into the tags of the same
+// name already there.
+type Merger interface {
+	Merge(into uuid.UUID, from []uuid.UUID) (Entity, error)
+}
+
+type Entity struct {
+	UUID uuid.UUID
+	Book uuid.UUID
+	Name string
+}
+
+func (e Entity) InBook() uuid.UUID { return e.Book }
+
+type ListEntity struct {
+	Offset       int
+	Length       int
+	Records      []Entity
+	TotalRecords int
+}
@@ -0,0 +1,281 @@
+package tagrepo
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/alan-b-lima/prp/internal/domain/tag"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+	"github.com/alan-b-lima/prp/pkg/uuid"
+)
+
+type Map struct {
+	uuidIndex map[uuid.UUID]int
+
+	repo []tag.Tag
+	mu   sync.RWMutex
+}
+
+func NewMap() tag.Repository {
+	repo := Map{
+		uuidIndex: make(map[uuid.UUID]int),
+	}
+
+	return &repo
+}
+
+func (m *Map) List(book uuid.UUID, offset, limit int) (tag.ListEntity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var scoped []*tag.Tag
+	for i := range m.repo {
+		if m.repo[i].Book() == book {
+			scoped = append(scoped, &m.repo[i])
+		}
+	}
+
+	// segment by segment, so that travel-x does not come between
+	// travel and travel/uber
+	slices.SortFunc(scoped, func(a, b *tag.Tag) int {
+		return slices.Compare(strings.Split(a.Name(), tag.Separator), strings.Split(b.Name(), tag.Separator))
+	})
+
+	lo := clamp(0, offset, len(scoped))
+	hi := clamp(0, offset+limit, len(scoped))
+
+	if lo >= hi {
+		return tag.ListEntity{TotalRecords: len(scoped)}, nil
+	}
+
+	res := make([]tag.Entity, hi-lo)
+	for i, t := range scoped[lo:hi] {
+		transform(&res[i], t)
+	}
+
+	return tag.ListEntity{
+		Offset:       lo,
+		Length:       len(res),
+		Records:      res,
+		TotalRecords: len(scoped),
+	}, nil
+}
+
+func (m *Map) Count(book uuid.UUID) (int, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	var count int
+	for i := range m.repo {
+		if m.repo[i].Book() == book {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (m *Map) Get(uuid uuid.UUID) (tag.Entity, error) {
+	defer m.mu.RUnlock()
+	m.mu.RLock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return tag.Entity{}, xerrors.ErrTagNotFound
+	}
+
+	var res tag.Entity
+	transform(&res, &m.repo[index])
+	return res, nil
+}
+
+func (m *Map) Create(book uuid.UUID, name string) (tag.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	t, err := tag.New(book, name)
+	if err != nil {
+		return tag.Entity{}, err
+	}
+
+	if _, taken := m.find(book, t.Name()); taken {
+		return tag.Entity{}, xerrors.ErrTagNameTaken.New(t.Name())
+	}
+
+	m.uuidIndex[t.UUID()] = len(m.repo)
+	m.repo = append(m.repo, t)
+
+	var res tag.Entity
+	transform(&res, &t)
+	return res, nil
+}
+
+func (m *Map) Rename(uuid uuid.UUID, name string) (tag.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return tag.Entity{}, xerrors.ErrTagNotFound
+	}
+
+	t := &m.repo[index]
+
+	name, err := tag.ProcessName(name)
+	if err != nil {
+		return tag.Entity{}, err
+	}
+
+	if name != t.Name() {
+		if tag.Within(name, t.Name()) {
+			return tag.Entity{}, xerrors.ErrTagUnderItself
+		}
+
+		moves := m.moves(t.Book(), t.Name(), name)
+		for _, to := range moves {
+			if other, taken := m.find(t.Book(), to); taken {
+				if _, moving := moves[other]; !moving {
+					return tag.Entity{}, xerrors.ErrTagNameTaken.New(to)
+				}
+			}
+		}
+
+		for i, to := range moves {
+			m.repo[i].SetName(to)
+		}
+	}
+
+	var res tag.Entity
+	transform(&res, t)
+	return res, nil
+}
+
+func (m *Map) Delete(uuid uuid.UUID) error {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	m.delete(uuid)
+	return nil
+}
+
+func (m *Map) Merge(into uuid.UUID, from []uuid.UUID) (tag.Entity, error) {
+	defer m.mu.Unlock()
+	m.mu.Lock()
+
+	index, in := m.uuidIndex[into]
+	if !in {
+		return tag.Entity{}, xerrors.ErrTagNotFound
+	}
+
+	t := m.repo[index]
+
+	// validate every source before touching anything, so a failed
+	// merge leaves the repository as it was
+	for _, uuid := range from {
+		index, in := m.uuidIndex[uuid]
+		if !in {
+			return tag.Entity{}, xerrors.ErrTagNotFound
+		}
+
+		if m.repo[index].Book() != t.Book() {
+			return tag.Entity{}, xerrors.ErrTagMergeBook
+		}
+
+		if tag.Within(t.Name(), m.repo[index].Name()) {
+			return tag.Entity{}, xerrors.ErrTagMergeSelf
+		}
+	}
+
+	// tags moved by this merge, either as a source or as a descendant
+	// of one, sources among them were merged already
+	moved := make(map[uuid.UUID]bool)
+
+	for _, src := range from {
+		if moved[src] {
+			continue
+		}
+
+		index := m.uuidIndex[src]
+		moves := m.moves(t.Book(), m.repo[index].Name(), t.Name())
+
+		// tags already at the new name absorb the moving ones, those
+		// still moving do not count, as they are renamed as well
+		var folded []uuid.UUID
+		for i, to := range moves {
+			moved[m.repo[i].UUID()] = true
+
+			if j, taken := m.find(t.Book(), to); taken {
+				if _, moving := moves[j]; !moving {
+					folded = append(folded, m.repo[i].UUID())
+					continue
+				}
+			}
+
+			m.repo[i].SetName(to)
+		}
+
+		for _, f := range folded {
+			m.delete(f)
+		}
+	}
+
+	var res tag.Entity
+	transform(&res, &m.repo[m.uuidIndex[into]])
+	return res, nil
+}
+
+// moves maps the indexes of the tag named from and its descendants to
+// their names once moved under to.
+func (m *Map) moves(book uuid.UUID, from, to string) map[int]string {
+	moves := make(map[int]string)
+	for i := range m.repo {
+		if m.repo[i].Book() != book {
+			continue
+		}
+
+		if name, ok := tag.Reparent(m.repo[i].Name(), from, to); ok {
+			moves[i] = name
+		}
+	}
+
+	return moves
+}
+
+func (m *Map) find(book uuid.UUID, name string) (int, bool) {
+	for i := range m.repo {
+		if m.repo[i].Book() == book && m.repo[i].Name() == name {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func (m *Map) delete(uuid uuid.UUID) {
+	index, in := m.uuidIndex[uuid]
+	if !in {
+		return
+	}
+
+	delete(m.uuidIndex, uuid)
+
+	last := len(m.repo) - 1
+	if index != last {
+		m.repo[index] = m.repo[last]
+		m.uuidIndex[m.repo[index].UUID()] = index
+	}
+
+	m.repo = m.repo[:last]
+}
+
+func transform(r *tag.Entity, t *tag.Tag) {
+	r.UUID = t.UUID()
+	r.Book = t.Book()
+	r.Name = t.Name()
+}
+
+func clamp[T cmp.Ordered](mn, val, mx T) T {
+	return min(max(mn, val), mx)
+}
@@ -0,0 +1,75 @@
+package tag
+
+import (
+	"github.com/alan-b-lima/prp/internal/auth"
+	"github.com/alan-b-lima/prp/internal/domain/book"
+	"github.com/alan-b-lima/prp/internal/xerrors"
+)
+
+type Service struct {
+	Repo  Repository
+	Books book.ContentGuard
+}
+
+func NewService(tags Repository, books book.ContentGuard) *Service {
+	return &Service{
+		Repo:  tags,
+		Books: books,
+	}
+}
+
+func (s *Service) List(ctx auth.Context, req ListRequest) (ListResponse, error) {
+	if err := book.Authorize(s.Books, ctx, req.Book, book.Viewer); err != nil {
+		return ListResponse{}, err
+	}
+
+	return List(s.Repo, req)
+}
+
+func (s *Service) Get(ctx auth.Context, req GetRequest) (Response, error) {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Viewer, s.Repo.Get, req.UUID, xerrors.ErrTagNotFound); err != nil {
+		return Response{}, err
+	}
+
+	return Get(s.Repo, req)
+}
+
+func (s *Service) Create(ctx auth.Context, req CreateRequest) (Response, error) {
+	defer s.Books.RLockContents(req.Book)()
+
+	if err := book.Authorize(s.Books, ctx, req.Book, book.Editor); err != nil {
+		return Response{}, err
+	}
+
+	return Create(s.Repo, req)
+}
+
+func (s *Service) Rename(ctx auth.Context, req RenameRequest) (Response, error) {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Editor, s.Repo.Get, req.UUID, xerrors.ErrTagNotFound); err != nil {
+		return Response{}, err
+	}
+
+	return Rename(s.Repo, req)
+}
+
+func (s *Service) Delete(ctx auth.Context, req DeleteRequest) error {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Editor, s.Repo.Get, req.UUID, xerrors.ErrTagNotFound); err != nil {
+		return err
+	}
+
+	return Delete(s.Repo, req)
+}
+
+func (s *Service) Merge(ctx auth.Context, req MergeRequest) (Response, error) {
+	if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Editor, s.Repo.Get, req.UUID, xerrors.ErrTagNotFound); err != nil {
+		return Response{}, err
+	}
+
+	for _, from := range req.From {
+		if err := book.AuthorizeOwned(s.Books, ctx, req.Book, book.Editor, s.Repo.Get, from, xerrors.ErrTagNotFound); err != nil {
+			return Response{}, err
+		}
+	}
+
+	return Merge(s.Repo, req)
+}
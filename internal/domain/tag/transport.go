@@ -0,0 +1,54 @@
+package tag
+
+import "github.com/alan-b-lima/prp/pkg/uuid"
+
+type (
+	ListRequest struct {
+		Book   uuid.UUID `json:"-"`
+		Offset int       `json:"-"`
+		Limit  int       `json:"-"`
+	}
+
+	GetRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	CreateRequest struct {
+		Book uuid.UUID `json:"-"`
+		Name string    `json:"name"`
+	}
+
+	RenameRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+		Name string    `json:"name"`
+	}
+
+	DeleteRequest struct {
+		Book uuid.UUID `json:"-"`
+		UUID uuid.UUID `json:"-"`
+	}
+
+	MergeRequest struct {
+		Book uuid.UUID   `json:"-"`
+		UUID uuid.UUID   `json:"-"`
+		From []uuid.UUID `json:"from"`
+	}
+)
+
+type (
+	ListResponse struct {
+		Offset       int        `json:"offset"`
+		Length       int        `json:"length"`
+		Records      []Response `json:"records"`
+		TotalRecords int        `json:"total_records"`
+	}
+
+	Response struct {
+		UUID   uuid.UUID `json:"uuid"`
+		Book   uuid.UUID `json:"book"`
+		Name   string    `json:"name"`
+		Parent string    `json:"parent"`
+	}
+)
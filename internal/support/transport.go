@@ -10,6 +10,7 @@ import (
 
 	"github.com/alan-b-lima/prp/internal/xerrors"
 	"github.com/alan-b-lima/prp/pkg/errors"
+	"github.com/alan-b-lima/prp/pkg/period"
 	uuidpkg "github.com/alan-b-lima/prp/pkg/uuid"
 )
 
@@ -44,6 +45,24 @@ func UUIDFromString(uuidStr string) (uuidpkg.UUID, error) {
 	return uuid, nil
 }
 
+func DateFromString(dateStr string) (period.Date, error) {
+	date, err := period.ParseDate(dateStr)
+	if err != nil {
+		return period.Date{}, xerrors.ErrBadDate.New(err)
+	}
+
+	return date, nil
+}
+
+func PeriodFromString(periodStr string, cal period.Calendar) (period.Period, error) {
+	p, err := cal.Parse(periodStr)
+	if err != nil {
+		return period.Period{}, xerrors.ErrBadPeriod.New(err)
+	}
+
+	return p, nil
+}
+
 func SessionCookie(cookie string, w http.ResponseWriter, r *http.Request) (uuidpkg.UUID, error) {
 	s, err := r.Cookie(cookie)
 	if err != nil {
@@ -3,7 +3,9 @@ package xerrors
 import "github.com/alan-b-lima/prp/pkg/errors"
 
 var (
-	ErrBadUUID = errors.Imp(errors.InvalidInput, "bad-uuid", "given UUID could not be parsed")
+	ErrBadUUID   = errors.Imp(errors.InvalidInput, "bad-uuid", "given UUID could not be parsed")
+	ErrBadDate   = errors.Imp(errors.InvalidInput, "bad-date", "given date could not be parsed")
+	ErrBadPeriod = errors.Imp(errors.InvalidInput, "bad-period", "given period could not be parsed")
 
 	ErrBadOffsetOrLimit = errors.Imp(errors.InvalidInput, "bad-offset-or-limit", "bad offset or limit params")
 
@@ -32,4 +34,95 @@ var (
 
 	ErrUserNotFound = errors.New(errors.NotFound, "user-not-found", "user not found", nil)
 	ErrLoginTaken   = errors.New(errors.Conflict, "login-in-use", "login already taken", nil)
+
+	ErrPayeeCreation = errors.Imp(errors.InvalidInput, "payee-creation", "given data does not satisfy the payee type")
+
+	ErrAliasEmpty  = errors.New(errors.InvalidInput, "alias-empty", "alias cannot be empty", nil)
+	ErrBadDocument = errors.Imp(errors.InvalidInput, "bad-document", "given document is not a valid CPF or CNPJ")
+
+	ErrPayeeNotFound  = errors.New(errors.NotFound, "payee-not-found", "payee not found", nil)
+	ErrPayeeMergeSelf = errors.New(errors.InvalidInput, "payee-merge-self", "payee cannot be merged into itself", nil)
+	ErrPayeeMergeBook = errors.New(errors.InvalidInput, "payee-merge-book", "payees of different books cannot be merged", nil)
+
+	ErrTagCreation = errors.Imp(errors.InvalidInput, "tag-creation", "given data does not satisfy the tag type")
+	ErrBadTagName  = errors.New(errors.InvalidInput, "bad-tag-name", "tag name must be made of non-empty segments separated by /, as in travel/uber", nil)
+
+	ErrTagNotFound    = errors.New(errors.NotFound, "tag-not-found", "tag not found", nil)
+	ErrTagNameTaken   = errors.Fmt(errors.Conflict, "tag-name-taken", "tag %v already exists in the book")
+	ErrTagUnderItself = errors.New(errors.InvalidInput, "tag-under-itself", "tag cannot be moved under itself", nil)
+	ErrTagMergeSelf   = errors.New(errors.InvalidInput, "tag-merge-self", "tag cannot be merged into itself nor into one of its descendants", nil)
+	ErrTagMergeBook   = errors.New(errors.InvalidInput, "tag-merge-book", "tags of different books cannot be merged", nil)
+
+	ErrLoanCreation    = errors.Imp(errors.InvalidInput, "loan-creation", "given data does not satisfy the loan type")
+	ErrBadLoanTerms    = errors.Imp(errors.InvalidInput, "bad-loan-terms", "given loan terms are invalid")
+	ErrLoanStartEmpty  = errors.New(errors.InvalidInput, "loan-start-empty", "loan start date cannot be empty", nil)
+	ErrBadExtraPayment = errors.Imp(errors.InvalidInput, "bad-extra-payment", "given extra payment is invalid")
+
+	ErrLoanNotFound = errors.New(errors.NotFound, "loan-not-found", "loan not found", nil)
+
+	ErrAssetCreation = errors.Imp(errors.InvalidInput, "asset-creation", "given data does not satisfy the asset type")
+	ErrBadAssetTerms = errors.Imp(errors.InvalidInput, "bad-asset-terms", "given depreciation terms are invalid")
+	ErrAcquiredEmpty = errors.New(errors.InvalidInput, "acquired-empty", "acquisition date cannot be empty", nil)
+	ErrBadDisposal   = errors.New(errors.InvalidInput, "bad-disposal", "disposal must not precede the acquisition nor have negative proceeds", nil)
+	ErrAssetDisposed = errors.New(errors.Conflict, "asset-disposed", "asset was already disposed", nil)
+
+	ErrAssetNotFound = errors.New(errors.NotFound, "asset-not-found", "asset not found", nil)
+
+	ErrInvoiceCreation = errors.Imp(errors.InvalidInput, "invoice-creation", "given data does not satisfy the invoice type")
+	ErrCustomerEmpty   = errors.New(errors.InvalidInput, "customer-empty", "customer cannot be empty", nil)
+	ErrIssuedEmpty     = errors.New(errors.InvalidInput, "issued-empty", "issue date cannot be empty", nil)
+	ErrDueBeforeIssue  = errors.New(errors.InvalidInput, "due-before-issue", "due date must not precede the issue date", nil)
+	ErrBadInvoiceItem  = errors.New(errors.InvalidInput, "bad-invoice-item", "items must have a description, a positive quantity up to a million and a unit price from zero up to a billion", nil)
+	ErrBadQuantity     = errors.New(errors.InvalidInput, "bad-quantity", "quantity must be a decimal number with up to three decimal places", nil)
+	ErrBadPayment      = errors.New(errors.InvalidInput, "bad-payment", "payment must be positive, not exceed the outstanding amount and not precede the issue date", nil)
+	ErrInvoiceTooLarge = errors.New(errors.InvalidInput, "invoice-too-large", "invoice total must not exceed a quadrillion", nil)
+	ErrInvoiceEmpty    = errors.New(errors.Conflict, "invoice-empty", "invoice must have a positive total to be sent", nil)
+	ErrInvoiceNotDraft = errors.New(errors.Conflict, "invoice-not-draft", "only draft invoices can be changed", nil)
+	ErrInvoiceNotSent  = errors.New(errors.Conflict, "invoice-not-sent", "only sent invoices can receive payments", nil)
+
+	ErrInvoiceNotFound = errors.New(errors.NotFound, "invoice-not-found", "invoice not found", nil)
+
+	ErrNotificationCreation = errors.Imp(errors.InvalidInput, "notification-creation", "given data does not satisfy the notification type")
+	ErrReminderCreation     = errors.Imp(errors.InvalidInput, "reminder-creation", "given data does not satisfy the reminder type")
+	ErrTitleEmpty           = errors.New(errors.InvalidInput, "title-empty", "title cannot be empty", nil)
+	ErrBadChannel           = errors.New(errors.InvalidInput, "bad-channel", "channel must be either inbox, email or webhook", nil)
+	ErrBadRepeat            = errors.New(errors.InvalidInput, "bad-repeat", "repeat must be either never, daily, weekly or monthly", nil)
+	ErrBadTimezone          = errors.Imp(errors.InvalidInput, "bad-timezone", "given timezone is not a known IANA timezone")
+	ErrBadEmail             = errors.Imp(errors.InvalidInput, "bad-email", "given email address could not be parsed")
+	ErrBadWebhook           = errors.New(errors.InvalidInput, "bad-webhook", "webhook must be an absolute http or https URL to a public host", nil)
+	ErrChannelUnconfigured  = errors.Fmt(errors.InvalidInput, "channel-unconfigured", "channel %v has no destination configured")
+	ErrBadReminderTime      = errors.Imp(errors.InvalidInput, "bad-reminder-time", "reminder time must be a local date and time, as in 2006-01-02T15:04")
+	ErrDeliveryFailed       = errors.Fmt(errors.BadGateway, "delivery-failed", "notification could not be delivered through %v")
+
+	ErrNotificationNotFound = errors.New(errors.NotFound, "notification-not-found", "notification not found", nil)
+	ErrReminderNotFound     = errors.New(errors.NotFound, "reminder-not-found", "reminder not found", nil)
+
+	ErrBookCreation = errors.Imp(errors.InvalidInput, "book-creation", "given data does not satisfy the book type")
+	ErrBadRole      = errors.New(errors.InvalidInput, "bad-role", "role must be one of owner, editor or viewer", nil)
+
+	ErrBookNotFound      = errors.New(errors.NotFound, "book-not-found", "book not found", nil)
+	ErrNotBookMember     = errors.New(errors.Forbidden, "not-book-member", "user is not a member of the book", nil)
+	ErrBookRoleTooLow    = errors.Fmt(errors.Forbidden, "book-role-too-low", "book role %v does not grant what %v does")
+	ErrBookLastOwner     = errors.New(errors.Conflict, "book-last-owner", "book must keep at least one owner", nil)
+	ErrAlreadyBookMember = errors.New(errors.Conflict, "already-book-member", "user is already a member of the book", nil)
+	ErrBookNotEmpty      = errors.Fmt(errors.Conflict, "book-not-empty", "book still has %v, they must be deleted first")
+
+	ErrAlreadyInvited     = errors.New(errors.Conflict, "already-invited", "user already has a pending invitation to the book", nil)
+	ErrInvitationNotFound = errors.New(errors.NotFound, "invitation-not-found", "invitation not found", nil)
+
+	ErrGroupCreation     = errors.Imp(errors.InvalidInput, "group-creation", "given data does not satisfy the group type")
+	ErrDescriptionEmpty  = errors.New(errors.InvalidInput, "description-empty", "description cannot be empty", nil)
+	ErrAmountNotPositive = errors.New(errors.InvalidInput, "amount-not-positive", "amount must be positive", nil)
+	ErrBadSplitMethod    = errors.New(errors.InvalidInput, "bad-split-method", "split must be one of equal, percentage or exact", nil)
+	ErrBadSplit          = errors.Imp(errors.InvalidInput, "bad-split", "given shares do not split the amount")
+	ErrDuplicateShare    = errors.New(errors.InvalidInput, "duplicate-share", "a member can only have one share per expense", nil)
+	ErrSettleSelf        = errors.New(errors.InvalidInput, "settle-self", "a member cannot settle with themselves", nil)
+
+	ErrGroupNotFound      = errors.New(errors.NotFound, "group-not-found", "group not found", nil)
+	ErrExpenseNotFound    = errors.New(errors.NotFound, "expense-not-found", "expense not found", nil)
+	ErrNotGroupMember     = errors.New(errors.Forbidden, "not-group-member", "user is not a member of the group", nil)
+	ErrAlreadyGroupMember = errors.New(errors.Conflict, "already-group-member", "user is already a member of the group", nil)
+	ErrGroupUnsettled     = errors.New(errors.Conflict, "group-unsettled", "balances must be settled first", nil)
+	ErrGroupUnbalanced    = errors.New(errors.Conflict, "group-unbalanced", "group balances do not add up to zero, some expense is not split exactly", nil)
+	ErrNotSettlementParty = errors.New(errors.Forbidden, "not-settlement-party", "only the payer or the payee may record a settlement", nil)
 )
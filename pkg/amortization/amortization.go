@@ -0,0 +1,339 @@
+// Copyright (C) 2025 Alan Barbosa Lima.
+//
+// PRP is licensed under the GNU General Public License
+// version 3. You should have received a copy of the
+// license, located in LICENSE, at the root of the source
+// tree. If not, see <https://www.gnu.org/licenses/>.
+
+// Package amortization generates amortization schedules for loans
+// under the Price (French) and SAC (constant amortization) systems,
+// the two common in Brazil. Amounts are integers in minor units,
+// cents for instance, every installment is rounded to the unit. The
+// last Price installment absorbs the residue, while constant
+// amortizations spread the remainder of the division a unit at a time
+// over the first installments.
+package amortization
+
+import (
+	"errors"
+	"math"
+)
+
+// System is an amortization system.
+type System int
+
+const (
+	_ System = iota
+
+	// Price has constant installments, the amortization grows as the
+	// interest shrinks. Also known as the French system.
+	Price
+
+	// SAC (Sistema de Amortização Constante) has constant
+	// amortization, the installments shrink with the interest.
+	SAC
+)
+
+var systemStrings = map[System]string{
+	Price: "price",
+	SAC:   "sac",
+}
+
+// ParseSystem parses the string representation of a System, as
+// returned by [System.String].
+func ParseSystem(str string) (System, error) {
+	for s, name := range systemStrings {
+		if name == str {
+			return s, nil
+		}
+	}
+
+	return 0, ErrBadSystem
+}
+
+// Implements the interface [fmt.Stringer] on the System type.
+func (s System) String() string {
+	return systemStrings[s]
+}
+
+// Reduce is what an extra payment reduces, since the balance drops
+// faster than scheduled, either the term or the installments must
+// shrink.
+type Reduce int
+
+const (
+	_ Reduce = iota
+
+	// Term keeps the installment (Price) or the amortization (SAC)
+	// and pays the loan off earlier.
+	Term
+
+	// Installment keeps the remaining term and recalculates the
+	// installments over the new balance.
+	Installment
+)
+
+var reduceStrings = map[Reduce]string{
+	Term:        "term",
+	Installment: "installment",
+}
+
+// ParseReduce parses the string representation of a Reduce, as
+// returned by [Reduce.String].
+func ParseReduce(str string) (Reduce, error) {
+	for r, name := range reduceStrings {
+		if name == str {
+			return r, nil
+		}
+	}
+
+	return 0, ErrBadReduce
+}
+
+// Implements the interface [fmt.Stringer] on the Reduce type.
+func (r Reduce) String() string {
+	return reduceStrings[r]
+}
+
+// Bounds of the loans a schedule is generated for, low enough that no
+// amount in it can overflow. MaxTerm is a hundred years of monthly
+// installments, MaxPrincipal a trillion reais in centavos and MaxRate
+// a hundred percent per period.
+const (
+	MaxTerm      = 1200
+	MaxPrincipal = 100_000_000_000_000
+	MaxRate      = 1.0
+)
+
+var (
+	ErrBadPrincipal   = errors.New("amortization: principal must be positive and at most a trillion")
+	ErrBadRate        = errors.New("amortization: rate must be between zero and one")
+	ErrBadTerm        = errors.New("amortization: term must be positive and at most 1200 periods")
+	ErrBadSystem      = errors.New("amortization: unknown amortization system")
+	ErrBadReduce      = errors.New("amortization: extra payment must reduce either term or installment")
+	ErrBadExtra       = errors.New("amortization: extra payment must be positive and within the term")
+	ErrNoAmortization = errors.New("amortization: installment is too small to amortize the balance")
+	ErrOutOfRange     = errors.New("amortization: amount out of range")
+)
+
+// Loan is the data a schedule is generated from.
+type Loan struct {
+	Principal int64
+	Rate      float64 // interest rate per period, 0.01 for 1%
+	Term      int     // number of periods
+	System    System
+	Extra     []Extra
+}
+
+// Extra is a payment on top of an installment, it goes entirely to
+// amortization.
+type Extra struct {
+	Period int // period of the installment it accompanies, 1-based
+	Amount int64
+	Reduce Reduce
+}
+
+// Row is an installment in a schedule. Payment is the sum of
+// Interest, Amortization and Extra.
+type Row struct {
+	Period       int
+	Payment      int64
+	Interest     int64
+	Amortization int64
+	Extra        int64
+	Balance      int64 // outstanding after the payment
+}
+
+// MonthlyRate converts an annual effective rate into the equivalent
+// monthly rate.
+func MonthlyRate(annual float64) float64 {
+	return math.Pow(1+annual, 1.0/12) - 1
+}
+
+// Validate checks whether a schedule can be generated for the loan.
+func (l *Loan) Validate() error {
+	if l.Principal <= 0 || l.Principal > MaxPrincipal {
+		return ErrBadPrincipal
+	}
+
+	if !(l.Rate >= 0 && l.Rate <= MaxRate) {
+		return ErrBadRate
+	}
+
+	if l.Term <= 0 || l.Term > MaxTerm {
+		return ErrBadTerm
+	}
+
+	if _, ok := systemStrings[l.System]; !ok {
+		return ErrBadSystem
+	}
+
+	for _, e := range l.Extra {
+		if err := e.Validate(l.Term); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks whether the extra payment fits a loan of the given
+// term.
+func (e *Extra) Validate(term int) error {
+	if e.Period < 1 || e.Period > term || e.Amount <= 0 {
+		return ErrBadExtra
+	}
+
+	if _, ok := reduceStrings[e.Reduce]; !ok {
+		return ErrBadReduce
+	}
+
+	return nil
+}
+
+// Schedule generates the amortization schedule of the loan, a row
+// per period, until the balance reaches zero. Extra payments beyond
+// the outstanding balance are capped to it, and those in periods
+// after the payoff are ignored. Extra payments reducing the term fail
+// with [ErrNoAmortization] when the kept installment cannot pay the
+// balance off, and never lengthen it, so a schedule has at most Term
+// rows.
+func Schedule(l Loan) ([]Row, error) {
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+
+	extras := make(map[int][]Extra, len(l.Extra))
+	for _, e := range l.Extra {
+		extras[e.Period] = append(extras[e.Period], e)
+	}
+
+	balance := l.Principal
+	remaining := l.Term
+	step, err := l.step(balance, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]Row, 0, l.Term)
+	for period := 1; balance > 0; period++ {
+		row := Row{Period: period}
+
+		row.Interest, err = round(float64(balance) * l.Rate)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case l.System == SAC || l.Rate == 0:
+			// recomputed every period, so the remainder of the division
+			// goes a unit at a time to the first installments and the
+			// schedule lasts exactly the remaining term
+			row.Amortization, err = l.step(balance, remaining)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			row.Amortization = step - row.Interest
+		}
+
+		if remaining <= 1 || row.Amortization > balance {
+			row.Amortization = balance
+		}
+
+		balance -= row.Amortization
+		remaining--
+
+		for _, e := range extras[period] {
+			extra := min(e.Amount, balance)
+			if extra == 0 {
+				continue
+			}
+
+			row.Extra += extra
+			balance -= extra
+
+			switch e.Reduce {
+			case Installment:
+				step, err = l.step(balance, remaining)
+				if err != nil {
+					return nil, err
+				}
+			case Term:
+				periods, err := l.periods(balance, step)
+				if err != nil {
+					return nil, err
+				}
+
+				remaining = min(remaining, periods)
+			}
+		}
+
+		row.Payment = row.Interest + row.Amortization + row.Extra
+		row.Balance = balance
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// step is the constant part of the installments, the installment
+// itself for Price and the amortization for SAC.
+func (l *Loan) step(balance int64, periods int) (int64, error) {
+	if periods <= 0 {
+		return balance, nil
+	}
+
+	if l.System == SAC {
+		return ceilDiv(balance, int64(periods)), nil
+	}
+
+	if l.Rate == 0 {
+		return ceilDiv(balance, int64(periods)), nil
+	}
+
+	i := l.Rate
+	return round(float64(balance) * i / (1 - math.Pow(1+i, -float64(periods))))
+}
+
+// periods is the amount of periods needed to pay off the balance
+// with the given step, the inverse of [Loan.step]. It fails if the
+// step never pays the balance off, as happens when it rounds to zero
+// or does not cover the interest.
+func (l *Loan) periods(balance, step int64) (int, error) {
+	if balance <= 0 {
+		return 0, nil
+	}
+
+	if step <= 0 {
+		return 0, ErrNoAmortization
+	}
+
+	if l.System == SAC || l.Rate == 0 {
+		return int(min(ceilDiv(balance, step), MaxTerm)), nil
+	}
+
+	i := l.Rate
+	ratio := float64(balance) * i / float64(step)
+	if ratio >= 1 {
+		return 0, ErrNoAmortization
+	}
+
+	n := math.Ceil(-math.Log(1-ratio) / math.Log(1+i))
+	return int(min(n, MaxTerm)), nil
+}
+
+// round rounds x to the nearest integer, failing if it does not fit
+// an int64 instead of converting it to an arbitrary value.
+func round(x float64) (int64, error) {
+	x = math.Round(x)
+	if !(x >= math.MinInt64 && x < math.MaxInt64) {
+		return 0, ErrOutOfRange
+	}
+
+	return int64(x), nil
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,264 @@
+package amortization_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/alan-b-lima/prp/pkg/amortization"
+)
+
+func TestPrice(t *testing.T) {
+	rows, err := Schedule(Loan{Principal: 100000, Rate: 0.01, Term: 12, System: Price})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 12 {
+		t.Fatalf("expected 12 installments, got %d", len(rows))
+	}
+
+	// 1000.00 at 1% a month for 12 months is 88.85 a month
+	for _, row := range rows[:11] {
+		if row.Payment != 8885 {
+			t.Errorf("installment %d should be 8885, got %d", row.Period, row.Payment)
+		}
+	}
+
+	if rows[0].Interest != 1000 || rows[0].Amortization != 7885 {
+		t.Errorf("unexpected first installment %+v", rows[0])
+	}
+
+	checkConsistency(t, 100000, rows)
+}
+
+func TestSAC(t *testing.T) {
+	rows, err := Schedule(Loan{Principal: 120000, Rate: 0.01, Term: 12, System: SAC})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 12 {
+		t.Fatalf("expected 12 installments, got %d", len(rows))
+	}
+
+	for i, row := range rows {
+		if row.Amortization != 10000 {
+			t.Errorf("amortization %d should be 10000, got %d", row.Period, row.Amortization)
+		}
+
+		if i > 0 && row.Payment >= rows[i-1].Payment {
+			t.Errorf("installment %d should be smaller than the previous one", row.Period)
+		}
+	}
+
+	checkConsistency(t, 120000, rows)
+}
+
+func TestZeroRate(t *testing.T) {
+	rows, err := Schedule(Loan{Principal: 1000, Rate: 0, Term: 3, System: Price})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 3 || rows[0].Payment != 334 || rows[1].Payment != 333 || rows[2].Payment != 333 {
+		t.Errorf("unexpected schedule %+v", rows)
+	}
+
+	checkConsistency(t, 1000, rows)
+}
+
+func TestExactTerm(t *testing.T) {
+	tests := []struct {
+		loan  Loan
+		first int64 // amortization of the first principal % term rows
+	}{
+		{Loan{Principal: 9, Rate: 0, Term: 4, System: SAC}, 3},
+		{Loan{Principal: 9, Rate: 0.01, Term: 4, System: SAC}, 3},
+		{Loan{Principal: 100, Rate: 0, Term: 30, System: Price}, 4},
+		{Loan{Principal: 100, Rate: 0, Term: 30, System: SAC}, 4},
+		{Loan{Principal: 100000, Rate: 0.01, Term: 12, System: SAC}, 8334},
+	}
+
+	for _, test := range tests {
+		l := test.loan
+		rows, err := Schedule(l)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(rows) != l.Term {
+			t.Errorf("%+v: expected %d installments, got %d", l, l.Term, len(rows))
+			continue
+		}
+
+		rem := int(l.Principal % int64(l.Term))
+		for i, row := range rows {
+			want := l.Principal / int64(l.Term)
+			if i < rem {
+				want = test.first
+			}
+
+			if row.Amortization != want {
+				t.Errorf("%+v: amortization %d should be %d, got %d", l, row.Period, want, row.Amortization)
+			}
+		}
+
+		checkConsistency(t, l.Principal, rows)
+	}
+}
+
+func TestExtraReducingTerm(t *testing.T) {
+	for _, system := range []System{Price, SAC} {
+		base, _ := Schedule(Loan{Principal: 100000, Rate: 0.01, Term: 24, System: system})
+
+		rows, err := Schedule(Loan{
+			Principal: 100000, Rate: 0.01, Term: 24, System: system,
+			Extra: []Extra{{Period: 6, Amount: 30000, Reduce: Term}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(rows) >= 24 {
+			t.Errorf("%v: term should have been reduced, got %d installments", system, len(rows))
+		}
+
+		if rows[6].Payment-rows[6].Extra != base[6].Payment-base[6].Extra && system == Price {
+			t.Errorf("%v: installment should have been kept", system)
+		}
+
+		checkConsistency(t, 100000, rows)
+	}
+}
+
+func TestExtraReducingInstallment(t *testing.T) {
+	for _, system := range []System{Price, SAC} {
+		base, _ := Schedule(Loan{Principal: 100000, Rate: 0.01, Term: 24, System: system})
+
+		rows, err := Schedule(Loan{
+			Principal: 100000, Rate: 0.01, Term: 24, System: system,
+			Extra: []Extra{{Period: 6, Amount: 30000, Reduce: Installment}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(rows) != 24 {
+			t.Errorf("%v: term should have been kept, got %d installments", system, len(rows))
+		}
+
+		if rows[6].Payment >= base[6].Payment {
+			t.Errorf("%v: installment should have been reduced", system)
+		}
+
+		checkConsistency(t, 100000, rows)
+	}
+}
+
+func TestExtraPayingOff(t *testing.T) {
+	rows, err := Schedule(Loan{
+		Principal: 100000, Rate: 0.01, Term: 24, System: Price,
+		Extra: []Extra{
+			{Period: 2, Amount: 1000000, Reduce: Term},
+			{Period: 3, Amount: 1000, Reduce: Term},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 2 || rows[1].Balance != 0 {
+		t.Errorf("loan should have been paid off in the second period, got %+v", rows)
+	}
+
+	checkConsistency(t, 100000, rows)
+}
+
+func TestExtraReducingTermTinyInstallment(t *testing.T) {
+	// the installment rounds to zero, so the kept installment never
+	// pays off what is left after the extra payment
+	_, err := Schedule(Loan{
+		Principal: 2, Rate: 0.001, Term: 360, System: Price,
+		Extra: []Extra{{Period: 1, Amount: 1, Reduce: Term}},
+	})
+	if err != ErrNoAmortization {
+		t.Errorf("expected %v, got %v", ErrNoAmortization, err)
+	}
+
+	rows, err := Schedule(Loan{Principal: 2, Rate: 0.001, Term: 360, System: Price})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 360 {
+		t.Errorf("expected 360 installments, got %d", len(rows))
+	}
+
+	checkConsistency(t, 2, rows)
+}
+
+func TestLimits(t *testing.T) {
+	// the largest loan at the highest rate must not overflow anywhere
+	for _, system := range []System{Price, SAC} {
+		for _, term := range []int{1, 2, MaxTerm} {
+			rows, err := Schedule(Loan{Principal: MaxPrincipal, Rate: MaxRate, Term: term, System: system})
+			if err != nil {
+				t.Fatalf("%v over %d periods: %v", system, term, err)
+			}
+
+			for _, row := range rows {
+				if row.Payment < 0 || row.Interest < 0 || row.Amortization < 0 || row.Balance < 0 {
+					t.Fatalf("%v over %d periods: negative amounts in %+v", system, term, row)
+				}
+			}
+
+			checkConsistency(t, MaxPrincipal, rows)
+		}
+	}
+}
+
+func TestInvalidLoans(t *testing.T) {
+	tests := []struct {
+		loan Loan
+		err  error
+	}{
+		{Loan{Principal: 0, Rate: 0.01, Term: 12, System: Price}, ErrBadPrincipal},
+		{Loan{Principal: MaxPrincipal + 1, Rate: 0.01, Term: 12, System: Price}, ErrBadPrincipal},
+		{Loan{Principal: math.MaxInt64, Rate: 0.01, Term: 2, System: SAC}, ErrBadPrincipal},
+		{Loan{Principal: 100, Rate: -0.01, Term: 12, System: Price}, ErrBadRate},
+		{Loan{Principal: 100, Rate: MaxRate + 0.01, Term: 12, System: Price}, ErrBadRate},
+		{Loan{Principal: 100, Rate: 1e300, Term: 12, System: Price}, ErrBadRate},
+		{Loan{Principal: 100, Rate: math.NaN(), Term: 12, System: Price}, ErrBadRate},
+		{Loan{Principal: 100, Rate: 0.01, Term: 0, System: Price}, ErrBadTerm},
+		{Loan{Principal: 100, Rate: 0.01, Term: MaxTerm + 1, System: Price}, ErrBadTerm},
+		{Loan{Principal: 100, Rate: 0.01, Term: 12}, ErrBadSystem},
+		{Loan{Principal: 100, Rate: 0.01, Term: 12, System: SAC, Extra: []Extra{{Period: 13, Amount: 1, Reduce: Term}}}, ErrBadExtra},
+		{Loan{Principal: 100, Rate: 0.01, Term: 12, System: SAC, Extra: []Extra{{Period: 1, Amount: 1}}}, ErrBadReduce},
+	}
+
+	for _, test := range tests {
+		if _, err := Schedule(test.loan); err != test.err {
+			t.Errorf("%+v should have failed with %v, got %v", test.loan, test.err, err)
+		}
+	}
+}
+
+func checkConsistency(t *testing.T, principal int64, rows []Row) {
+	t.Helper()
+
+	balance := principal
+	for _, row := range rows {
+		if row.Payment != row.Interest+row.Amortization+row.Extra {
+			t.Errorf("installment %d does not add up: %+v", row.Period, row)
+		}
+
+		balance -= row.Amortization + row.Extra
+		if balance != row.Balance {
+			t.Errorf("balance after installment %d should be %d, got %d", row.Period, balance, row.Balance)
+		}
+	}
+
+	if balance != 0 {
+		t.Errorf("loan should have been paid off, %d remains", balance)
+	}
+}
@@ -0,0 +1,366 @@
+// Copyright (C) 2025 Alan Barbosa Lima.
+//
+// PRP is licensed under the GNU General Public License
+// version 3. You should have received a copy of the
+// license, located in LICENSE, at the root of the source
+// tree. If not, see <https://www.gnu.org/licenses/>.
+
+// Package blob implements a content-addressed blob store on the
+// local filesystem. Blobs are keyed by the SHA-256 of their content,
+// so storing the same content twice yields the same key and a single
+// copy on disk.
+//
+// The store keeps no metadata besides the content itself, the media
+// type of a blob is sniffed from its first bytes whenever needed.
+package blob
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Key is the SHA-256 of the content of a blob. Elements of this type
+// can, and should, be compared using the == operator.
+type Key [sha256.Size]byte
+
+var (
+	ErrBadKey         = errors.New("blob: key is not a hex encoded SHA-256")
+	ErrNotFound       = errors.New("blob: blob not found")
+	ErrTooLarge       = errors.New("blob: content exceeds the maximum size")
+	ErrUnacceptedType = errors.New("blob: content type is not accepted")
+)
+
+// ParseKey parses the hex representation of a key, as returned by
+// [Key.String].
+func ParseKey(str string) (Key, error) {
+	var key Key
+	if hex.DecodedLen(len(str)) != len(key) {
+		return Key{}, ErrBadKey
+	}
+
+	if _, err := hex.Decode(key[:], []byte(str)); err != nil {
+		return Key{}, ErrBadKey
+	}
+
+	return key, nil
+}
+
+// Implements the interface [fmt.Stringer] on the Key type.
+func (key Key) String() string {
+	return hex.EncodeToString(key[:])
+}
+
+// Implements the interface [encoding.TextMarshaler] on the Key type.
+func (key Key) MarshalText() ([]byte, error) {
+	return []byte(key.String()), nil
+}
+
+// Implements the interface [encoding.TextUnmarshaler] on the Key
+// type.
+func (key *Key) UnmarshalText(buf []byte) error {
+	parsed, err := ParseKey(string(buf))
+	if err != nil {
+		return err
+	}
+
+	*key = parsed
+	return nil
+}
+
+// Info describes a stored blob.
+type Info struct {
+	Key     Key
+	Size    int64
+	Type    string
+	ModTime time.Time
+}
+
+// Options configures a [Store].
+type Options struct {
+	// MaxSize is the maximum size in bytes of a blob, zero means no
+	// limit.
+	MaxSize int64
+
+	// Accept lists the media types accepted by [Store.Put], without
+	// parameters, as in "image/png". An empty list accepts any.
+	Accept []string
+}
+
+// Store is a blob store rooted at a directory. It's safe to use a
+// Store from multiple goroutines, but not to share its directory
+// with another Store.
+type Store struct {
+	root string
+	opts Options
+	mu   sync.RWMutex
+}
+
+const _SniffLen = 512
+
+// Open opens the store rooted at the given directory, creating it if
+// needed.
+func Open(root string, opts Options) (*Store, error) {
+	if err := os.MkdirAll(filepath.Join(root, "tmp"), 0o755); err != nil {
+		return nil, err
+	}
+
+	return &Store{root: root, opts: opts}, nil
+}
+
+// Put stores the content read from r and returns its info. If the
+// content is already stored, nothing is written and the existing
+// blob has its modification time refreshed, see [Store.GC] for why.
+func (s *Store) Put(r io.Reader) (Info, error) {
+	tmp, err := os.CreateTemp(filepath.Join(s.root, "tmp"), "put-")
+	if err != nil {
+		return Info{}, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if s.opts.MaxSize > 0 {
+		r = io.LimitReader(r, s.opts.MaxSize+1)
+	}
+
+	h := sha256.New()
+	head := bytes.NewBuffer(make([]byte, 0, _SniffLen))
+
+	size, err := io.Copy(io.MultiWriter(tmp, h, &prefix{head}), r)
+	if err != nil {
+		return Info{}, err
+	}
+
+	if s.opts.MaxSize > 0 && size > s.opts.MaxSize {
+		return Info{}, ErrTooLarge
+	}
+
+	typ := http.DetectContentType(head.Bytes())
+	if !s.accepts(typ) {
+		return Info{}, ErrUnacceptedType
+	}
+
+	if err := tmp.Close(); err != nil {
+		return Info{}, err
+	}
+
+	key := Key(h.Sum(nil))
+	path := s.path(key)
+
+	defer s.mu.Unlock()
+	s.mu.Lock()
+
+	now := time.Now()
+	if _, err := os.Stat(path); err == nil {
+		if err := os.Chtimes(path, now, now); err != nil {
+			return Info{}, err
+		}
+
+		return Info{Key: key, Size: size, Type: typ, ModTime: now}, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return Info{}, err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return Info{}, err
+	}
+
+	return Info{Key: key, Size: size, Type: typ, ModTime: now}, nil
+}
+
+// Stat returns the info of the blob with the given key.
+func (s *Store) Stat(key Key) (Info, error) {
+	f, info, err := s.Open(key)
+	if err != nil {
+		return Info{}, err
+	}
+
+	f.Close()
+	return info, nil
+}
+
+// Open opens the blob with the given key for reading, the caller is
+// responsible for closing it.
+func (s *Store) Open(key Key) (*os.File, Info, error) {
+	defer s.mu.RUnlock()
+	s.mu.RLock()
+
+	f, err := os.Open(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, Info{}, ErrNotFound
+	}
+	if err != nil {
+		return nil, Info{}, err
+	}
+
+	info, err := stat(f, key)
+	if err != nil {
+		f.Close()
+		return nil, Info{}, err
+	}
+
+	return f, info, nil
+}
+
+// inline are the media types browsers are let to display, they run
+// no script on the origin serving them. Anything else, HTML and SVG
+// in particular, is served as a download.
+var inline = []string{
+	"application/pdf",
+	"image/gif",
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+	"text/plain",
+}
+
+// Serve writes the blob with the given key as the response to r,
+// with its sniffed Content-Type. Blobs are user content, so browsers
+// are told not to sniff it, and only the types in inline are not
+// served as attachments. Range and conditional requests are handled
+// by [http.ServeContent].
+func (s *Store) Serve(w http.ResponseWriter, r *http.Request, key Key) error {
+	f, info, err := s.Open(key)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", info.Type)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("ETag", `"`+key.String()+`"`)
+
+	if media, _, err := mime.ParseMediaType(info.Type); err != nil || !slices.Contains(inline, media) {
+		w.Header().Set("Content-Disposition", "attachment")
+	}
+
+	http.ServeContent(w, r, "", info.ModTime, f)
+	return nil
+}
+
+// Delete removes the blob with the given key, removing a blob that
+// does not exist is not an error.
+func (s *Store) Delete(key Key) error {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// GC removes every blob for which referenced returns false, and
+// returns the keys of the removed blobs.
+//
+// Only blobs last modified before the given time are considered, so
+// that a blob stored, but not yet referenced by its owner, survives
+// a concurrent collection. Putting existing content refreshes its
+// modification time for the same reason.
+func (s *Store) GC(before time.Time, referenced func(Key) bool) ([]Key, error) {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+
+	var removed []Key
+
+	err := filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path == filepath.Join(s.root, "tmp") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		key, err := ParseKey(d.Name())
+		if err != nil {
+			return nil
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if !fi.ModTime().Before(before) || referenced(key) {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+
+		removed = append(removed, key)
+		return nil
+	})
+
+	return removed, err
+}
+
+func (s *Store) accepts(typ string) bool {
+	if len(s.opts.Accept) == 0 {
+		return true
+	}
+
+	media, _, err := mime.ParseMediaType(typ)
+	if err != nil {
+		return false
+	}
+
+	return slices.Contains(s.opts.Accept, media)
+}
+
+func (s *Store) path(key Key) string {
+	str := key.String()
+	return filepath.Join(s.root, str[:2], str)
+}
+
+func stat(f *os.File, key Key) (Info, error) {
+	fi, err := f.Stat()
+	if err != nil {
+		return Info{}, err
+	}
+
+	var head [_SniffLen]byte
+	n, err := f.ReadAt(head[:], 0)
+	if err != nil && err != io.EOF {
+		return Info{}, err
+	}
+
+	return Info{
+		Key:     key,
+		Size:    fi.Size(),
+		Type:    http.DetectContentType(head[:n]),
+		ModTime: fi.ModTime(),
+	}, nil
+}
+
+// prefix is a writer that keeps only what fits in the capacity of
+// its buffer and discards the rest.
+type prefix struct{ buf *bytes.Buffer }
+
+func (p *prefix) Write(b []byte) (int, error) {
+	if room := p.buf.Cap() - p.buf.Len(); room > 0 {
+		p.buf.Write(b[:min(room, len(b))])
+	}
+
+	return len(b), nil
+}
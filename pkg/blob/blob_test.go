@@ -0,0 +1,191 @@
+package blob_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/alan-b-lima/prp/pkg/blob"
+)
+
+var _PDF = []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n1 0 obj\n<<>>\nendobj\ntrailer\n<<>>\n%%EOF\n")
+
+func TestPutIsContentAddressedAndDeduplicated(t *testing.T) {
+	store, err := Open(t.TempDir(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i0, err := store.Put(bytes.NewReader(_PDF))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i1, err := store.Put(bytes.NewReader(_PDF))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if i0.Key != Key(sha256.Sum256(_PDF)) {
+		t.Errorf("key %v should be the SHA-256 of the content", i0.Key)
+	}
+
+	if i0.Key != i1.Key {
+		t.Errorf("%v and %v should be equal", i0.Key, i1.Key)
+	}
+
+	if i0.Type != "application/pdf" || i0.Size != int64(len(_PDF)) {
+		t.Errorf("unexpected info %+v", i0)
+	}
+
+	f, info, err := store.Open(i0.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	content, _ := io.ReadAll(f)
+	if !bytes.Equal(content, _PDF) || info != (Info{i0.Key, i0.Size, i0.Type, info.ModTime}) {
+		t.Errorf("stored blob does not match what was put: %+v", info)
+	}
+}
+
+func TestPutRestrictions(t *testing.T) {
+	store, err := Open(t.TempDir(), Options{MaxSize: 64, Accept: []string{"application/pdf"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Put(bytes.NewReader(_PDF)); err != nil {
+		t.Errorf("following error shouldn't have happened: %v", err)
+	}
+
+	large := append(bytes.Clone(_PDF), make([]byte, 64)...)
+	if _, err := store.Put(bytes.NewReader(large)); err != ErrTooLarge {
+		t.Errorf("expected %v, got %v", ErrTooLarge, err)
+	}
+
+	if _, err := store.Put(bytes.NewReader([]byte("plain text"))); err != ErrUnacceptedType {
+		t.Errorf("expected %v, got %v", ErrUnacceptedType, err)
+	}
+}
+
+func TestServeRange(t *testing.T) {
+	store, err := Open(t.TempDir(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := store.Put(bytes.NewReader(_PDF))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Range", "bytes=0-3")
+	w := httptest.NewRecorder()
+
+	if err := store.Serve(w, r, info.Key); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Code != http.StatusPartialContent {
+		t.Errorf("expected status %d, got %d", http.StatusPartialContent, w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/pdf" {
+		t.Errorf("expected application/pdf, got %s", ct)
+	}
+
+	if body := w.Body.String(); body != "%PDF" {
+		t.Errorf("expected %q, got %q", "%PDF", body)
+	}
+}
+
+func TestServeHeaders(t *testing.T) {
+	store, err := Open(t.TempDir(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		content     []byte
+		disposition string
+	}{
+		{_PDF, ""},
+		{[]byte("plain text"), ""},
+		{[]byte("<html><script>alert(1)</script></html>"), "attachment"},
+		{[]byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`), "attachment"},
+	}
+
+	for _, test := range tests {
+		info, err := store.Put(bytes.NewReader(test.content))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w := httptest.NewRecorder()
+		if err := store.Serve(w, httptest.NewRequest(http.MethodGet, "/", nil), info.Key); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := w.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+			t.Errorf("%s: expected nosniff, got %q", info.Type, got)
+		}
+
+		if got := w.Header().Get("Content-Disposition"); got != test.disposition {
+			t.Errorf("%s: expected disposition %q, got %q", info.Type, test.disposition, got)
+		}
+	}
+}
+
+func TestGC(t *testing.T) {
+	store, err := Open(t.TempDir(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make([]Key, 10)
+	for i := range keys {
+		buf := make([]byte, 100)
+		rand.Read(buf)
+
+		info, err := store.Put(bytes.NewReader(buf))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keys[i] = info.Key
+	}
+
+	referenced := func(k Key) bool { return k == keys[0] }
+
+	removed, err := store.GC(time.Now().Add(-time.Hour), referenced)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 0 {
+		t.Errorf("recent blobs should have survived, removed %v", removed)
+	}
+
+	removed, err = store.GC(time.Now().Add(time.Hour), referenced)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != len(keys)-1 {
+		t.Errorf("expected %d blobs removed, got %d", len(keys)-1, len(removed))
+	}
+
+	if _, err := store.Stat(keys[0]); err != nil {
+		t.Errorf("referenced blob should have survived: %v", err)
+	}
+
+	if _, err := store.Stat(keys[1]); err != ErrNotFound {
+		t.Errorf("expected %v, got %v", ErrNotFound, err)
+	}
+}
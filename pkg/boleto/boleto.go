@@ -0,0 +1,310 @@
+// Copyright (C) 2025 Alan Barbosa Lima.
+//
+// PRP is licensed under the GNU General Public License
+// version 3. You should have received a copy of the
+// license, located in LICENSE, at the root of the source
+// tree. If not, see <https://www.gnu.org/licenses/>.
+
+// Package boleto validates and decodes Brazilian boletos, given either
+// the 44 digit barcode or the typeable line ("linha digitável"), of 47
+// digits for bank boletos and 48 digits for utility and tax collection
+// ones ("arrecadação").
+package boleto
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Kind int
+
+const (
+	_ Kind = iota
+	Bank
+	Utility
+)
+
+var kindStrings = map[Kind]string{
+	Bank:    "bank",
+	Utility: "utility",
+}
+
+func (k Kind) String() string {
+	return kindStrings[k]
+}
+
+type Boleto struct {
+	Kind    Kind
+	Barcode string
+
+	// Bank and Factor are only set for bank boletos, a zero Factor
+	// means the boleto has no due date.
+	Bank   string
+	Factor int
+
+	// Segment is only set for utility boletos, it tells the kind of
+	// collector, such as 2 for sanitation or 3 for energy and gas.
+	Segment int
+
+	// Amount is in cents, zero if the boleto leaves it open or, for
+	// utility boletos, carries a reference amount instead.
+	Amount int64
+}
+
+var (
+	ErrBadLength     = errors.New("boleto: must have 44 (barcode), 47 (bank line) or 48 (utility line) digits")
+	ErrBadCharacter  = errors.New("boleto: contains an invalid character")
+	ErrBadCheckDigit = errors.New("boleto: check digits do not match")
+	ErrBadCurrency   = errors.New("boleto: currency must be the Brazilian real")
+	ErrBadKind       = errors.New("boleto: barcode kind does not match the line")
+	ErrBadSegment    = errors.New("boleto: unknown utility segment")
+	ErrBadValueID    = errors.New("boleto: unknown utility value identifier")
+)
+
+// Parse parses a barcode or typeable line, spaces, dots and hyphens
+// are ignored.
+func Parse(str string) (Boleto, error) {
+	var b strings.Builder
+	for i := 0; i < len(str); i++ {
+		switch c := str[i]; {
+		case '0' <= c && c <= '9':
+			b.WriteByte(c)
+		case c == ' ' || c == '.' || c == '-':
+		default:
+			return Boleto{}, ErrBadCharacter
+		}
+	}
+
+	digits := b.String()
+	switch len(digits) {
+	case 44:
+		return parseBarcode(digits)
+	case 47:
+		return parseBankLine(digits)
+	case 48:
+		return parseUtilityLine(digits)
+	}
+
+	return Boleto{}, ErrBadLength
+}
+
+func parseBarcode(code string) (Boleto, error) {
+	if code[0] == '8' {
+		return parseUtility(code)
+	}
+
+	return parseBank(code)
+}
+
+func parseBank(code string) (Boleto, error) {
+	if code[3] != '9' {
+		return Boleto{}, ErrBadCurrency
+	}
+
+	if digit(code[4]) != mod11Bank(code[:4]+code[5:]) {
+		return Boleto{}, ErrBadCheckDigit
+	}
+
+	factor, _ := strconv.Atoi(code[5:9])
+	amount, _ := strconv.ParseInt(code[9:19], 10, 64)
+
+	return Boleto{
+		Kind:    Bank,
+		Barcode: code,
+		Bank:    code[:3],
+		Factor:  factor,
+		Amount:  amount,
+	}, nil
+}
+
+// parseBankLine rebuilds the barcode from the five fields of the line,
+// the first three of which carry a check digit of their own.
+func parseBankLine(line string) (Boleto, error) {
+	fields := [...]string{line[0:10], line[10:21], line[21:32]}
+	for _, f := range fields {
+		if digit(f[len(f)-1]) != mod10(f[:len(f)-1]) {
+			return Boleto{}, ErrBadCheckDigit
+		}
+	}
+
+	code := line[0:4] + line[32:33] + line[33:47] + line[4:9] + line[10:20] + line[21:31]
+	if code[0] == '8' {
+		return Boleto{}, ErrBadKind
+	}
+
+	return parseBank(code)
+}
+
+func parseUtility(code string) (Boleto, error) {
+	segment := digit(code[1])
+	if segment == 0 {
+		return Boleto{}, ErrBadSegment
+	}
+
+	check, err := utilityModule(code[2])
+	if err != nil {
+		return Boleto{}, err
+	}
+
+	if digit(code[3]) != check(code[:3]+code[4:]) {
+		return Boleto{}, ErrBadCheckDigit
+	}
+
+	var amount int64
+	if code[2] == '6' || code[2] == '8' {
+		amount, _ = strconv.ParseInt(code[4:15], 10, 64)
+	}
+
+	return Boleto{
+		Kind:    Utility,
+		Barcode: code,
+		Segment: segment,
+		Amount:  amount,
+	}, nil
+}
+
+// parseUtilityLine rebuilds the barcode from the four blocks of the
+// line, each made of 11 digits of the barcode and a check digit.
+func parseUtilityLine(line string) (Boleto, error) {
+	if line[0] != '8' {
+		return Boleto{}, ErrBadKind
+	}
+
+	check, err := utilityModule(line[2])
+	if err != nil {
+		return Boleto{}, err
+	}
+
+	var code strings.Builder
+	for i := 0; i < 48; i += 12 {
+		block := line[i : i+11]
+		if digit(line[i+11]) != check(block) {
+			return Boleto{}, ErrBadCheckDigit
+		}
+
+		code.WriteString(block)
+	}
+
+	return parseUtility(code.String())
+}
+
+// utilityModule is the check digit algorithm used by utility boletos,
+// which depends on the value identifier.
+func utilityModule(id byte) (func(string) int, error) {
+	switch id {
+	case '6', '7':
+		return mod10, nil
+	case '8', '9':
+		return mod11Utility, nil
+	}
+
+	return nil, ErrBadValueID
+}
+
+// Line is the typeable line of the boleto, grouped as printed.
+func (b *Boleto) Line() string {
+	code := b.Barcode
+
+	if b.Kind == Utility {
+		check, _ := utilityModule(code[2])
+
+		var line strings.Builder
+		for i := 0; i < 44; i += 11 {
+			if i > 0 {
+				line.WriteByte(' ')
+			}
+
+			block := code[i : i+11]
+			line.WriteString(block)
+			line.WriteByte('-')
+			line.WriteByte(byte('0' + check(block)))
+		}
+
+		return line.String()
+	}
+
+	f1 := code[0:4] + code[19:24]
+	f2 := code[24:34]
+	f3 := code[34:44]
+
+	f1 += strconv.Itoa(mod10(f1))
+	f2 += strconv.Itoa(mod10(f2))
+	f3 += strconv.Itoa(mod10(f3))
+
+	return f1[:5] + "." + f1[5:] + " " +
+		f2[:5] + "." + f2[5:] + " " +
+		f3[:5] + "." + f3[5:] + " " +
+		code[4:5] + " " + code[5:19]
+}
+
+// FactorBase is the day of due date factor zero. Factors go up to 9999
+// and then restart from 1000, which happened on 2025-02-22.
+var FactorBase = time.Date(1997, time.October, 7, 0, 0, 0, 0, time.UTC)
+
+// FactorCycle is the amount of days after which factors repeat.
+const FactorCycle = 9000
+
+// DueDate is the due date of a bank boleto. As factors repeat, the
+// date is the one closest to now among those the factor can stand for.
+func (b *Boleto) DueDate(now time.Time) (time.Time, bool) {
+	if b.Kind != Bank || b.Factor == 0 {
+		return time.Time{}, false
+	}
+
+	y, m, d := now.Date()
+	days := int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Sub(FactorBase).Hours() / 24)
+
+	cycle := 0
+	if days > b.Factor {
+		cycle = (days - b.Factor + FactorCycle/2) / FactorCycle
+	}
+
+	return FactorBase.AddDate(0, 0, b.Factor+cycle*FactorCycle), true
+}
+
+func digit(c byte) int { return int(c - '0') }
+
+// mod10 alternates weights 2 and 1 from the right, summing the digits
+// of each product.
+func mod10(digits string) int {
+	var sum int
+	for i := len(digits) - 1; i >= 0; i-- {
+		p := digit(digits[i])
+		if (len(digits)-1-i)%2 == 0 {
+			p *= 2
+		}
+
+		sum += p/10 + p%10
+	}
+
+	return (10 - sum%10) % 10
+}
+
+// mod11 cycles weights 2 through 9 from the right, returning 11 minus
+// the remainder of the sum.
+func mod11(digits string) int {
+	var sum int
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += digit(digits[i]) * (2 + (len(digits)-1-i)%8)
+	}
+
+	return 11 - sum%11
+}
+
+func mod11Bank(digits string) int {
+	if dv := mod11(digits); dv < 10 {
+		return dv
+	}
+
+	return 1
+}
+
+func mod11Utility(digits string) int {
+	if dv := mod11(digits); dv < 10 {
+		return dv
+	}
+
+	return 0
+}
@@ -0,0 +1,111 @@
+package boleto_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/alan-b-lima/prp/pkg/boleto"
+)
+
+const (
+	bankCode = "00193373700000001000500940144816060680935031"
+	bankLine = "00190.50095 40144.816069 06809.350314 3 37370000000100"
+
+	utilityCode   = "83680000001234500482026101512345678901234567"
+	utilityLine   = "83680000001-7 23450048202-6 61015123456-0 78901234567-2"
+	utilityLine11 = "83860000001-8 23450048202-8 61015123456-1 78901234567-5"
+)
+
+func TestParseBank(t *testing.T) {
+	for _, str := range []string{bankCode, bankLine} {
+		b, err := Parse(str)
+		if err != nil {
+			t.Errorf("%s: %v", str, err)
+			continue
+		}
+
+		if b.Kind != Bank || b.Barcode != bankCode || b.Bank != "001" || b.Factor != 3737 || b.Amount != 100 {
+			t.Errorf("%s: unexpected boleto %+v", str, b)
+		}
+
+		if line := b.Line(); line != bankLine {
+			t.Errorf("%s: expected line %s, got %s", str, bankLine, line)
+		}
+	}
+}
+
+func TestParseUtility(t *testing.T) {
+	for _, str := range []string{utilityCode, utilityLine, utilityLine11} {
+		b, err := Parse(str)
+		if err != nil {
+			t.Errorf("%s: %v", str, err)
+			continue
+		}
+
+		if b.Kind != Utility || b.Segment != 3 || b.Amount != 12345 {
+			t.Errorf("%s: unexpected boleto %+v", str, b)
+		}
+
+		if len(str) > 44 && b.Line() != str {
+			t.Errorf("%s: line renders as %s", str, b.Line())
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		str string
+		err error
+	}{
+		{"0019337370000000100050094014481606068093503", ErrBadLength},
+		{"0019337370000000100050094014481606068093503x", ErrBadCharacter},
+		{"00194373700000001000500940144816060680935031", ErrBadCheckDigit},
+		{"00193373700000001000500940144816060680935032", ErrBadCheckDigit},
+		{"00183373700000001000500940144816060680935031", ErrBadCurrency},
+		{"00190.50096 40144.816069 06809.350314 3 37370000000100", ErrBadCheckDigit},
+		{"00190.50095 40144.816069 06809.350314 4 37370000000100", ErrBadCheckDigit},
+		{"83680000001-7 23450048202-6 61015123456-1 78901234567-2", ErrBadCheckDigit},
+		{"83580000001-7 23450048202-6 61015123456-0 78901234567-2", ErrBadValueID},
+		{"80680000001234500482026101512345678901234567", ErrBadSegment},
+		{"93680000001-7 23450048202-6 61015123456-0 78901234567-2", ErrBadKind},
+	}
+
+	for _, test := range tests {
+		if _, err := Parse(test.str); err != test.err {
+			t.Errorf("%s: expected %v, got %v", test.str, test.err, err)
+		}
+	}
+}
+
+func TestDueDate(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		factor int
+		now    time.Time
+		due    time.Time
+	}{
+		{1000, date(2000, time.July, 1), date(2000, time.July, 3)},
+		{3737, date(2008, time.January, 1), date(2007, time.December, 31)},
+		{9999, date(2025, time.February, 20), date(2025, time.February, 21)},
+		{1000, date(2025, time.February, 20), date(2025, time.February, 22)},
+		{1000, date(2026, time.October, 19), date(2025, time.February, 22)},
+		{1667, date(2026, time.October, 19), date(2026, time.December, 21)},
+		{9999, date(2026, time.October, 19), date(2025, time.February, 21)},
+	}
+
+	for _, test := range tests {
+		b := Boleto{Kind: Bank, Factor: test.factor}
+		due, ok := b.DueDate(test.now)
+		if !ok || !due.Equal(test.due) {
+			t.Errorf("factor %d on %s: expected %s, got %s", test.factor, test.now.Format(time.DateOnly), test.due.Format(time.DateOnly), due.Format(time.DateOnly))
+		}
+	}
+
+	b := Boleto{Kind: Bank}
+	if _, ok := b.DueDate(time.Now()); ok {
+		t.Error("factor zero should have no due date")
+	}
+}
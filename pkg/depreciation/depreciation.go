@@ -0,0 +1,189 @@
+// Copyright (C) 2025 Alan Barbosa Lima.
+//
+// PRP is licensed under the GNU General Public License
+// version 3. You should have received a copy of the
+// license, located in LICENSE, at the root of the source
+// tree. If not, see <https://www.gnu.org/licenses/>.
+
+// Package depreciation generates depreciation schedules for fixed
+// assets under the straight-line and declining balance methods.
+// Amounts are integers in minor units, cents for instance, and the
+// schedule always ends exactly at the residual value.
+package depreciation
+
+import (
+	"errors"
+	"math"
+)
+
+// Method is a depreciation method.
+type Method int
+
+const (
+	_ Method = iota
+
+	// StraightLine depreciates the same amount every period.
+	StraightLine
+
+	// DecliningBalance depreciates a fixed fraction of the book
+	// value every period, switching to straight-line once it
+	// depreciates more, so the residual value is reached by the end
+	// of the useful life.
+	DecliningBalance
+)
+
+var methodStrings = map[Method]string{
+	StraightLine:     "straight-line",
+	DecliningBalance: "declining-balance",
+}
+
+// ParseMethod parses the string representation of a Method, as
+// returned by [Method.String].
+func ParseMethod(str string) (Method, error) {
+	for m, name := range methodStrings {
+		if name == str {
+			return m, nil
+		}
+	}
+
+	return 0, ErrBadMethod
+}
+
+// Implements the interface [fmt.Stringer] on the Method type.
+func (m Method) String() string {
+	return methodStrings[m]
+}
+
+// DefaultFactor is the factor used by [DecliningBalance] when none
+// is given, which makes it the double declining balance method.
+const DefaultFactor = 2
+
+// MaxLife is the longest useful life a schedule is generated for, a
+// hundred years of monthly periods.
+const MaxLife = 1200
+
+var (
+	ErrBadCost     = errors.New("depreciation: cost must be positive")
+	ErrBadResidual = errors.New("depreciation: residual value must be between zero and the cost")
+	ErrBadLife     = errors.New("depreciation: useful life must be positive and at most 1200 periods")
+	ErrBadMethod   = errors.New("depreciation: unknown depreciation method")
+	ErrBadFactor   = errors.New("depreciation: declining balance factor must be positive")
+)
+
+// Asset is the data a schedule is generated from.
+type Asset struct {
+	Cost     int64
+	Residual int64
+	Life     int // useful life, in periods
+	Method   Method
+	Factor   float64 // declining balance factor, zero for DefaultFactor
+}
+
+// Row is a period in a schedule.
+type Row struct {
+	Period       int
+	Depreciation int64
+	Accumulated  int64
+	BookValue    int64 // cost minus accumulated depreciation
+}
+
+// Validate checks whether a schedule can be generated for the asset.
+func (a *Asset) Validate() error {
+	if a.Cost <= 0 {
+		return ErrBadCost
+	}
+
+	if a.Residual < 0 || a.Residual > a.Cost {
+		return ErrBadResidual
+	}
+
+	if a.Life <= 0 || a.Life > MaxLife {
+		return ErrBadLife
+	}
+
+	if _, ok := methodStrings[a.Method]; !ok {
+		return ErrBadMethod
+	}
+
+	if a.Factor < 0 || math.IsNaN(a.Factor) || math.IsInf(a.Factor, 0) {
+		return ErrBadFactor
+	}
+
+	return nil
+}
+
+// Schedule generates the depreciation schedule of the asset, a row
+// per period of its useful life.
+func Schedule(a Asset) ([]Row, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	factor := a.Factor
+	if factor == 0 {
+		factor = DefaultFactor
+	}
+
+	depreciable := a.Cost - a.Residual
+	rate := factor / float64(a.Life)
+
+	var accumulated int64
+	rows := make([]Row, a.Life)
+	for i := range rows {
+		period := i + 1
+		remaining := a.Life - i
+
+		var dep int64
+		switch a.Method {
+		case StraightLine:
+			// rounding the accumulated amount instead of each
+			// period spreads the residue along the schedule
+			dep = round(float64(depreciable)*float64(period)/float64(a.Life)) - accumulated
+
+		case DecliningBalance:
+			left := depreciable - accumulated
+			dep = max(
+				round(float64(a.Cost-accumulated)*rate),
+				ceilDiv(left, int64(remaining)),
+			)
+		}
+
+		if remaining == 1 || dep > depreciable-accumulated {
+			dep = depreciable - accumulated
+		}
+
+		accumulated += dep
+		rows[i] = Row{
+			Period:       period,
+			Depreciation: dep,
+			Accumulated:  accumulated,
+			BookValue:    a.Cost - accumulated,
+		}
+	}
+
+	return rows, nil
+}
+
+// BookValue is the book value of the asset after the given amount of
+// periods, the cost before the first and the residual value after
+// the last.
+func BookValue(a Asset, periods int) (int64, error) {
+	rows, err := Schedule(a)
+	if err != nil {
+		return 0, err
+	}
+
+	if periods <= 0 {
+		return a.Cost, nil
+	}
+
+	return rows[min(periods, len(rows))-1].BookValue, nil
+}
+
+func round(x float64) int64 {
+	return int64(math.Round(x))
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
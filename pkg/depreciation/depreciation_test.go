@@ -0,0 +1,160 @@
+package depreciation_test
+
+import (
+	"testing"
+
+	. "github.com/alan-b-lima/prp/pkg/depreciation"
+)
+
+func TestStraightLine(t *testing.T) {
+	rows, err := Schedule(Asset{Cost: 500000, Residual: 20000, Life: 48, Method: StraightLine})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 48 {
+		t.Fatalf("expected 48 periods, got %d", len(rows))
+	}
+
+	for _, row := range rows {
+		if row.Depreciation != 10000 {
+			t.Errorf("depreciation %d should be 10000, got %d", row.Period, row.Depreciation)
+		}
+	}
+
+	checkConsistency(t, 500000, 20000, rows)
+}
+
+func TestStraightLineResidue(t *testing.T) {
+	rows, err := Schedule(Asset{Cost: 1000, Life: 3, Method: StraightLine})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, row := range rows {
+		if row.Depreciation < 333 || row.Depreciation > 334 {
+			t.Errorf("depreciation %d should be about a third, got %d", row.Period, row.Depreciation)
+		}
+	}
+
+	checkConsistency(t, 1000, 0, rows)
+}
+
+func TestDecliningBalance(t *testing.T) {
+	rows, err := Schedule(Asset{Cost: 100000, Residual: 10000, Life: 10, Method: DecliningBalance})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 10 {
+		t.Fatalf("expected 10 periods, got %d", len(rows))
+	}
+
+	// double declining balance over 10 periods is 20% of the book value
+	if rows[0].Depreciation != 20000 || rows[1].Depreciation != 16000 {
+		t.Errorf("unexpected first periods %+v %+v", rows[0], rows[1])
+	}
+
+	for i := 1; i < len(rows); i++ {
+		if rows[i].Depreciation > rows[i-1].Depreciation {
+			t.Errorf("depreciation %d should not grow", rows[i].Period)
+		}
+	}
+
+	checkConsistency(t, 100000, 10000, rows)
+}
+
+func TestDecliningBalanceFactor(t *testing.T) {
+	rows, err := Schedule(Asset{Cost: 100000, Life: 10, Method: DecliningBalance, Factor: 1.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rows[0].Depreciation != 15000 {
+		t.Errorf("first period should be 15000, got %d", rows[0].Depreciation)
+	}
+
+	checkConsistency(t, 100000, 0, rows)
+}
+
+func TestBookValue(t *testing.T) {
+	asset := Asset{Cost: 120000, Residual: 0, Life: 12, Method: StraightLine}
+
+	tests := []struct {
+		periods int
+		want    int64
+	}{
+		{0, 120000},
+		{-1, 120000},
+		{1, 110000},
+		{6, 60000},
+		{12, 0},
+		{24, 0},
+	}
+
+	for _, test := range tests {
+		got, err := BookValue(asset, test.periods)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != test.want {
+			t.Errorf("after %d periods, expected %d, got %d", test.periods, test.want, got)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		asset Asset
+		want  error
+	}{
+		{Asset{Cost: 0, Life: 1, Method: StraightLine}, ErrBadCost},
+		{Asset{Cost: 100, Residual: -1, Life: 1, Method: StraightLine}, ErrBadResidual},
+		{Asset{Cost: 100, Residual: 101, Life: 1, Method: StraightLine}, ErrBadResidual},
+		{Asset{Cost: 100, Life: 0, Method: StraightLine}, ErrBadLife},
+		{Asset{Cost: 100, Life: MaxLife + 1, Method: StraightLine}, ErrBadLife},
+		{Asset{Cost: 100, Life: 1 << 40, Method: DecliningBalance}, ErrBadLife},
+		{Asset{Cost: 100, Life: 1}, ErrBadMethod},
+		{Asset{Cost: 100, Life: 1, Method: DecliningBalance, Factor: -1}, ErrBadFactor},
+	}
+
+	for _, test := range tests {
+		if _, err := Schedule(test.asset); err != test.want {
+			t.Errorf("%+v: expected %v, got %v", test.asset, test.want, err)
+		}
+	}
+}
+
+func TestParseMethod(t *testing.T) {
+	for _, m := range []Method{StraightLine, DecliningBalance} {
+		got, err := ParseMethod(m.String())
+		if err != nil || got != m {
+			t.Errorf("round trip of %v failed: %v, %v", m, got, err)
+		}
+	}
+
+	if _, err := ParseMethod("sum-of-years"); err != ErrBadMethod {
+		t.Errorf("expected %v, got %v", ErrBadMethod, err)
+	}
+}
+
+func checkConsistency(t *testing.T, cost, residual int64, rows []Row) {
+	t.Helper()
+
+	var accumulated int64
+	for _, row := range rows {
+		if row.Depreciation < 0 {
+			t.Errorf("period %d has negative depreciation", row.Period)
+		}
+
+		accumulated += row.Depreciation
+		if row.Accumulated != accumulated || row.BookValue != cost-accumulated {
+			t.Errorf("period %d is inconsistent: %+v", row.Period, row)
+		}
+	}
+
+	if last := rows[len(rows)-1]; last.BookValue != residual {
+		t.Errorf("schedule should end at %d, ended at %d", residual, last.BookValue)
+	}
+}
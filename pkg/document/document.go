@@ -0,0 +1,202 @@
+// Copyright (C) 2025 Alan Barbosa Lima.
+//
+// PRP is licensed under the GNU General Public License
+// version 3. You should have received a copy of the
+// license, located in LICENSE, at the root of the source
+// tree. If not, see <https://www.gnu.org/licenses/>.
+
+// Package document implements parsing and validation of Brazilian
+// registry numbers, namely CPF, for natural persons, and CNPJ, for
+// legal entities. Both carry two check digits, computed with a
+// weighted sum modulo 11, which are verified on parsing.
+package document
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Kind is the kind of a registry number.
+type Kind int
+
+const (
+	None Kind = iota
+	CPF
+	CNPJ
+)
+
+var kindStrings = map[Kind]string{
+	None: "",
+	CPF:  "cpf",
+	CNPJ: "cnpj",
+}
+
+// String implements the [fmt.Stringer] interface on the Kind type.
+func (k Kind) String() string {
+	return kindStrings[k]
+}
+
+// Document is a validated CPF or CNPJ, it holds only the significant
+// characters of the number, without any punctuation. Its zero value
+// represents the absence of a document.
+type Document struct {
+	kind   Kind
+	number string
+}
+
+var (
+	ErrBadLength     = errors.New("document: number must have 11 (CPF) or 14 (CNPJ) characters")
+	ErrBadCharacter  = errors.New("document: number contains an invalid character")
+	ErrBadCheckDigit = errors.New("document: check digits do not match")
+	ErrRepeated      = errors.New("document: number made of a single repeated digit")
+)
+
+// Parse parses a CPF or a CNPJ, the kind is inferred from the amount
+// of significant characters. The punctuation usually found in those
+// numbers, that is, dots, dashes, slashes and spaces, is ignored.
+//
+// CNPJs are accepted in the alphanumeric format, where the first 12
+// characters may be uppercase letters, letters are valued by their
+// ASCII code minus 48, as specified by the Receita Federal. The
+// empty string parses to the zero Document.
+func Parse(str string) (Document, error) {
+	var b strings.Builder
+	b.Grow(len(str))
+
+	for _, r := range str {
+		switch {
+		case r == '.' || r == '-' || r == '/' || r == ' ':
+			continue
+		case '0' <= r && r <= '9', 'A' <= r && r <= 'Z':
+			b.WriteRune(r)
+		case 'a' <= r && r <= 'z':
+			b.WriteRune(r - 'a' + 'A')
+		default:
+			return Document{}, ErrBadCharacter
+		}
+	}
+
+	number := b.String()
+	switch len(number) {
+	case 0:
+		return Document{}, nil
+	case 11:
+		return parse(CPF, number, 9)
+	case 14:
+		return parse(CNPJ, number, 12)
+	}
+
+	return Document{}, ErrBadLength
+}
+
+func parse(kind Kind, number string, base int) (Document, error) {
+	for i := range len(number) {
+		if isDigit(number[i]) {
+			continue
+		}
+
+		if kind == CPF || i >= base {
+			return Document{}, ErrBadCharacter
+		}
+	}
+
+	if strings.Count(number, number[:1]) == len(number) {
+		return Document{}, ErrRepeated
+	}
+
+	var weights func(int, int) int
+	if kind == CPF {
+		weights = cpfWeight
+	} else {
+		weights = cnpjWeight
+	}
+
+	for n := base; n < len(number); n++ {
+		if checkDigit(number[:n], weights) != number[n] {
+			return Document{}, ErrBadCheckDigit
+		}
+	}
+
+	return Document{kind: kind, number: number}, nil
+}
+
+// checkDigit computes the check digit following the prefix, weights
+// receives the position of a character and the length of the prefix.
+func checkDigit(prefix string, weight func(int, int) int) byte {
+	var sum int
+	for i := range len(prefix) {
+		sum += int(prefix[i]-'0') * weight(i, len(prefix))
+	}
+
+	rem := sum % 11
+	if rem < 2 {
+		return '0'
+	}
+
+	return byte('0' + 11 - rem)
+}
+
+func cpfWeight(i, n int) int  { return n + 1 - i }
+func cnpjWeight(i, n int) int { return (n-1-i)%8 + 2 }
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+// Kind returns the kind of the document, [None] for the zero value.
+func (d Document) Kind() Kind { return d.kind }
+
+// Number returns the significant characters of the document.
+func (d Document) Number() string { return d.number }
+
+// IsZero reports whether the document is the zero Document.
+func (d Document) IsZero() bool { return d.kind == None }
+
+// String implements the [fmt.Stringer] interface on the Document
+// type, it formats the number with its usual mask, 000.000.000-00
+// for CPFs and 00.000.000/0000-00 for CNPJs.
+func (d Document) String() string {
+	n := d.number
+
+	switch d.kind {
+	case CPF:
+		return n[0:3] + "." + n[3:6] + "." + n[6:9] + "-" + n[9:11]
+	case CNPJ:
+		return n[0:2] + "." + n[2:5] + "." + n[5:8] + "/" + n[8:12] + "-" + n[12:14]
+	}
+
+	return ""
+}
+
+// MarshalJSON implements the [json.Marshaler] interface, the
+// document is marshalled as its masked string, the zero Document is
+// marshalled as JSON's null.
+func (d Document) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte(`null`), nil
+	}
+
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface, the
+// input should be a JSON string, which is parsed with [Parse], or
+// JSON's null.
+func (d *Document) UnmarshalJSON(buf []byte) error {
+	if string(buf) == `null` {
+		*d = Document{}
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(buf, &str); err != nil {
+		return err
+	}
+
+	doc, err := Parse(str)
+	if err != nil {
+		return err
+	}
+
+	*d = doc
+	return nil
+}
@@ -0,0 +1,71 @@
+package document_test
+
+import (
+	"testing"
+
+	. "github.com/alan-b-lima/prp/pkg/document"
+)
+
+func TestValidDocuments(t *testing.T) {
+	tests := []struct {
+		input  string
+		kind   Kind
+		masked string
+	}{
+		{"529.982.247-25", CPF, "529.982.247-25"},
+		{"52998224725", CPF, "529.982.247-25"},
+		{"111.444.777-35", CPF, "111.444.777-35"},
+		{"11.222.333/0001-81", CNPJ, "11.222.333/0001-81"},
+		{"11222333000181", CNPJ, "11.222.333/0001-81"},
+		{"12.ABC.345/01DE-35", CNPJ, "12.ABC.345/01DE-35"},
+		{"12abc34501de35", CNPJ, "12.ABC.345/01DE-35"},
+	}
+
+	for _, test := range tests {
+		doc, err := Parse(test.input)
+		if err != nil {
+			t.Errorf("%q should have parsed, got: %v", test.input, err)
+			continue
+		}
+
+		if doc.Kind() != test.kind {
+			t.Errorf("%q should be a %v, got %v", test.input, test.kind, doc.Kind())
+		}
+
+		if doc.String() != test.masked {
+			t.Errorf("%q should format as %q, got %q", test.input, test.masked, doc.String())
+		}
+	}
+}
+
+func TestInvalidDocuments(t *testing.T) {
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{"529.982.247-24", ErrBadCheckDigit},
+		{"11.222.333/0001-80", ErrBadCheckDigit},
+		{"111.111.111-11", ErrRepeated},
+		{"1234", ErrBadLength},
+		{"529.982.247_25", ErrBadCharacter},
+		{"5299822472A", ErrBadCharacter},
+		{"12.ABC.345/01DE-3A", ErrBadCharacter},
+	}
+
+	for _, test := range tests {
+		if _, err := Parse(test.input); err != test.err {
+			t.Errorf("%q should have failed with %v, got: %v", test.input, test.err, err)
+		}
+	}
+}
+
+func TestEmptyDocument(t *testing.T) {
+	doc, err := Parse("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !doc.IsZero() {
+		t.Errorf("empty string should parse to the zero document, got %v", doc)
+	}
+}
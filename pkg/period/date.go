@@ -0,0 +1,126 @@
+// Copyright (C) 2025 Alan Barbosa Lima.
+//
+// PRP is licensed under the GNU General Public License
+// version 3. You should have received a copy of the
+// license, located in LICENSE, at the root of the source
+// tree. If not, see <https://www.gnu.org/licenses/>.
+
+package period
+
+import (
+	"cmp"
+	"time"
+)
+
+// Date is a calendar day, with no time of day or location attached,
+// so that the day of an entry does not shift when seen from another
+// time zone. The zero value is the absent date.
+type Date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// NewDate is the date of the given day, normalized as [time.Date]
+// does, so that October 32 is November 1.
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf is the day of t in its own location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{year: y, month: m, day: d}
+}
+
+// Today is the current day in the given location.
+func Today(loc *time.Location) Date {
+	return DateOf(time.Now().In(loc))
+}
+
+// ParseDate parses a date in the YYYY-MM-DD format. The empty string
+// is the zero date.
+func ParseDate(str string) (Date, error) {
+	if str == "" {
+		return Date{}, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, str)
+	if err != nil {
+		return Date{}, ErrBadDate
+	}
+
+	return DateOf(t), nil
+}
+
+func (d Date) Year() int             { return d.year }
+func (d Date) Month() time.Month     { return d.month }
+func (d Date) Day() int              { return d.day }
+func (d Date) Weekday() time.Weekday { return d.utc().Weekday() }
+func (d Date) IsZero() bool          { return d == Date{} }
+
+// Time is the start of the day in the given location.
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, loc)
+}
+
+func (d Date) utc() time.Time { return d.Time(time.UTC) }
+
+func (d Date) AddDays(n int) Date {
+	return NewDate(d.year, d.month, d.day+n)
+}
+
+// AddMonths moves the date by n months, keeping the day unless the
+// target month is shorter, in which case its last day is taken, so
+// January 31 plus one month is the end of February.
+func (d Date) AddMonths(n int) Date {
+	first := NewDate(d.year, d.month+time.Month(n), 1)
+	return NewDate(first.year, first.month, min(d.day, daysIn(first.year, first.month)))
+}
+
+// Sub is the amount of days from u to d, negative if d precedes u.
+func (d Date) Sub(u Date) int {
+	return int((d.utc().Unix() - u.utc().Unix()) / (24 * 60 * 60))
+}
+
+// Compare returns -1, 0 or +1 whether d is before, the same as or
+// after u.
+func (d Date) Compare(u Date) int {
+	switch {
+	case d.year != u.year:
+		return cmp.Compare(d.year, u.year)
+	case d.month != u.month:
+		return cmp.Compare(d.month, u.month)
+	}
+
+	return cmp.Compare(d.day, u.day)
+}
+
+func (d Date) Before(u Date) bool { return d.Compare(u) < 0 }
+func (d Date) After(u Date) bool  { return d.Compare(u) > 0 }
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return d.utc().Format(time.DateOnly)
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(text []byte) error {
+	date, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = date
+	return nil
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
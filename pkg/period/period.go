@@ -0,0 +1,333 @@
+// Copyright (C) 2025 Alan Barbosa Lima.
+//
+// PRP is licensed under the GNU General Public License
+// version 3. You should have received a copy of the
+// license, located in LICENSE, at the root of the source
+// tree. If not, see <https://www.gnu.org/licenses/>.
+
+// Package period implements calendar days and the periods reports,
+// budgets and closings are made of: months, quarters and years, the
+// latter two possibly fiscal, starting in a month other than January.
+//
+// Periods are written as
+//
+//	2026-03        March 2026
+//	2026-Q1        first quarter of 2026
+//	2026           year 2026
+//	FY2026         fiscal year 2026, starting as per the calendar
+//	FY2026-Q1      first quarter of fiscal year 2026
+//	FY2026/04      fiscal year 2026, starting in April
+//	FY2026-Q1/04   first quarter of fiscal year 2026, starting in April
+//
+// Fiscal years are named after the year they end in, so fiscal year
+// 2026 starting in April runs from April 2025 to March 2026.
+package period
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBadDate   = errors.New("period: date must be in the YYYY-MM-DD format")
+	ErrBadPeriod = errors.New("period: period must be a month (2026-03), quarter (2026-Q1), year (2026) or fiscal year (FY2026)")
+	ErrBadStart  = errors.New("period: fiscal year start must be a month")
+)
+
+type Kind int
+
+const (
+	_ Kind = iota
+	Month
+	Quarter
+	Year
+)
+
+var kindStrings = map[Kind]string{
+	Month:   "month",
+	Quarter: "quarter",
+	Year:    "year",
+}
+
+func (k Kind) String() string {
+	return kindStrings[k]
+}
+
+func (k Kind) months() int {
+	switch k {
+	case Month:
+		return 1
+	case Quarter:
+		return 3
+	case Year:
+		return 12
+	}
+
+	return 0
+}
+
+// Calendar tells in which month fiscal years start, the zero value is
+// the calendar year, starting in January.
+type Calendar struct {
+	Start time.Month
+}
+
+func (c Calendar) start() time.Month {
+	if c.Start < time.January || c.Start > time.December {
+		return time.January
+	}
+
+	return c.Start
+}
+
+// Period is the period of the given kind containing the date.
+func (c Calendar) Period(kind Kind, d Date) Period {
+	if kind == Month {
+		return Period{kind: Month, start: NewDate(d.year, d.month, 1), fiscal: time.January}
+	}
+
+	fiscal := c.start()
+
+	year := d.year
+	if d.month < fiscal {
+		year--
+	}
+
+	p := Period{kind: Year, start: NewDate(year, fiscal, 1), fiscal: fiscal}
+	if kind == Quarter {
+		months := (d.year-p.start.year)*12 + int(d.month-p.start.month)
+		p = Period{kind: Quarter, start: p.start.AddMonths(months / 3 * 3), fiscal: fiscal}
+	}
+
+	return p
+}
+
+func (c Calendar) Month(d Date) Period   { return c.Period(Month, d) }
+func (c Calendar) Quarter(d Date) Period { return c.Period(Quarter, d) }
+func (c Calendar) Year(d Date) Period    { return c.Period(Year, d) }
+
+// Parse parses a period, fiscal ones with no explicit start month
+// start as per the calendar. The empty string is the zero period.
+func (c Calendar) Parse(str string) (Period, error) {
+	if str == "" {
+		return Period{}, nil
+	}
+
+	fiscal := time.January
+
+	rest, isFiscal := strings.CutPrefix(str, "FY")
+	if isFiscal {
+		fiscal = c.start()
+
+		if r, start, ok := strings.Cut(rest, "/"); ok {
+			m, err := number(start, 2)
+			if err != nil || m < 1 || m > 12 {
+				return Period{}, ErrBadStart
+			}
+
+			rest, fiscal = r, time.Month(m)
+		}
+	}
+
+	yearStr, sub, hasSub := strings.Cut(rest, "-")
+
+	year, err := number(yearStr, 4)
+	if err != nil {
+		return Period{}, err
+	}
+
+	// The fiscal year starts in the year before it is named after,
+	// unless it starts in January.
+	start := NewDate(year, fiscal, 1)
+	if fiscal != time.January {
+		start = start.AddMonths(-12)
+	}
+
+	if !hasSub {
+		return Period{kind: Year, start: start, fiscal: fiscal}, nil
+	}
+
+	if q, ok := strings.CutPrefix(sub, "Q"); ok {
+		n, err := number(q, 1)
+		if err != nil || n < 1 || n > 4 {
+			return Period{}, ErrBadPeriod
+		}
+
+		return Period{kind: Quarter, start: start.AddMonths((n - 1) * 3), fiscal: fiscal}, nil
+	}
+
+	if isFiscal {
+		return Period{}, ErrBadPeriod
+	}
+
+	m, err := number(sub, 2)
+	if err != nil || m < 1 || m > 12 {
+		return Period{}, ErrBadPeriod
+	}
+
+	return Period{kind: Month, start: NewDate(year, time.Month(m), 1), fiscal: time.January}, nil
+}
+
+// Parse parses a period in the calendar year.
+func Parse(str string) (Period, error) {
+	return Calendar{}.Parse(str)
+}
+
+// Period is a month, quarter or year. Periods are comparable, equal
+// periods cover the same days and are of the same kind.
+type Period struct {
+	kind   Kind
+	start  Date
+	fiscal time.Month
+}
+
+func (p Period) Kind() Kind   { return p.kind }
+func (p Period) IsZero() bool { return p == Period{} }
+
+// Calendar is the calendar the period belongs to, months belong to
+// every calendar and report the calendar year.
+func (p Period) Calendar() Calendar { return Calendar{Start: p.fiscal} }
+
+// Start is the first day of the period.
+func (p Period) Start() Date { return p.start }
+
+// End is the last day of the period.
+func (p Period) End() Date { return p.start.AddMonths(p.kind.months()).AddDays(-1) }
+
+func (p Period) Contains(d Date) bool {
+	return !d.Before(p.start) && !d.After(p.End())
+}
+
+// Len is the amount of days in the period.
+func (p Period) Len() int {
+	return p.End().Sub(p.start) + 1
+}
+
+// Add is the period n periods of the same kind away.
+func (p Period) Add(n int) Period {
+	p.start = p.start.AddMonths(n * p.kind.months())
+	return p
+}
+
+func (p Period) Next() Period { return p.Add(+1) }
+func (p Period) Prev() Period { return p.Add(-1) }
+
+// Periods iterates over the periods of the given kind the period is
+// made of, in order. Nothing is yielded for kinds longer than that of
+// the period.
+func (p Period) Periods(kind Kind) iter.Seq[Period] {
+	return func(yield func(Period) bool) {
+		if kind.months() == 0 || kind.months() > p.kind.months() {
+			return
+		}
+
+		sub := p.Calendar().Period(kind, p.start)
+		for ; !sub.start.After(p.End()); sub = sub.Next() {
+			if !yield(sub) {
+				return
+			}
+		}
+	}
+}
+
+// Days iterates over the days of the period, in order.
+func (p Period) Days() iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		end := p.End()
+		for d := p.start; !d.After(end); d = d.AddDays(1) {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// Range iterates over the periods from one to the other, inclusive,
+// both must be of the same kind and calendar.
+func Range(from, to Period) iter.Seq[Period] {
+	return func(yield func(Period) bool) {
+		if from.kind != to.kind || from.fiscal != to.fiscal {
+			return
+		}
+
+		for p := from; !p.start.After(to.start); p = p.Next() {
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// FiscalYear is the year the fiscal year containing the period is
+// named after.
+func (p Period) FiscalYear() int {
+	if p.fiscal == time.January || p.start.month < p.fiscal {
+		return p.start.year
+	}
+
+	return p.start.year + 1
+}
+
+func (p Period) String() string {
+	if p.IsZero() {
+		return ""
+	}
+
+	if p.kind == Month {
+		return fmt.Sprintf("%04d-%02d", p.start.year, p.start.month)
+	}
+
+	var b strings.Builder
+	if p.fiscal != time.January {
+		b.WriteString("FY")
+	}
+
+	fmt.Fprintf(&b, "%04d", p.FiscalYear())
+
+	if p.kind == Quarter {
+		months := (int(p.start.month) - int(p.fiscal) + 12) % 12
+		fmt.Fprintf(&b, "-Q%d", months/3+1)
+	}
+
+	if p.fiscal != time.January {
+		fmt.Fprintf(&b, "/%02d", p.fiscal)
+	}
+
+	return b.String()
+}
+
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText parses the period in the calendar it already belongs
+// to, if any.
+func (p *Period) UnmarshalText(text []byte) error {
+	period, err := p.Calendar().Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*p = period
+	return nil
+}
+
+// number parses a non-negative number of exactly the given amount of
+// digits.
+func number(str string, digits int) (int, error) {
+	if len(str) != digits {
+		return 0, ErrBadPeriod
+	}
+
+	for i := 0; i < len(str); i++ {
+		if str[i] < '0' || str[i] > '9' {
+			return 0, ErrBadPeriod
+		}
+	}
+
+	return strconv.Atoi(str)
+}
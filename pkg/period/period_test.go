@@ -0,0 +1,248 @@
+package period_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/alan-b-lima/prp/pkg/period"
+)
+
+func TestDate(t *testing.T) {
+	d := NewDate(2024, time.January, 31)
+
+	if got := d.AddMonths(1); got != NewDate(2024, time.February, 29) {
+		t.Errorf("January 31 plus a month should be February 29, got %v", got)
+	}
+
+	if got := d.AddMonths(-2); got != NewDate(2023, time.November, 30) {
+		t.Errorf("January 31 minus two months should be November 30, got %v", got)
+	}
+
+	if got := d.AddDays(30); got != NewDate(2024, time.March, 1) {
+		t.Errorf("January 31 plus 30 days should be March 1, got %v", got)
+	}
+
+	if n := NewDate(2025, time.January, 1).Sub(NewDate(2024, time.January, 1)); n != 366 {
+		t.Errorf("2024 should have 366 days, got %d", n)
+	}
+
+	if d.Weekday() != time.Wednesday {
+		t.Errorf("2024-01-31 should be a Wednesday, got %v", d.Weekday())
+	}
+
+	if !d.Before(d.AddDays(1)) || d.After(d) || d.Compare(d) != 0 {
+		t.Error("dates compare wrongly")
+	}
+
+	// 02:00 UTC is still the previous day in São Paulo.
+	sp := time.FixedZone("BRT", -3*60*60)
+	instant := time.Date(2026, time.March, 1, 2, 0, 0, 0, time.UTC)
+	if got := DateOf(instant.In(sp)); got != NewDate(2026, time.February, 28) {
+		t.Errorf("expected 2026-02-28, got %v", got)
+	}
+
+	if got := DateOf(d.Time(sp)); got != d {
+		t.Errorf("date should survive a round trip through a location, got %v", got)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	d, err := ParseDate("2026-10-19")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d != NewDate(2026, time.October, 19) || d.String() != "2026-10-19" {
+		t.Errorf("unexpected date %v", d)
+	}
+
+	for _, str := range []string{"2026-13-01", "2026-02-30", "19/10/2026", "2026-10-19T00:00"} {
+		if _, err := ParseDate(str); err != ErrBadDate {
+			t.Errorf("%s: expected %v, got %v", str, ErrBadDate, err)
+		}
+	}
+
+	if d, err := ParseDate(""); err != nil || !d.IsZero() {
+		t.Errorf("the empty string should be the zero date, got %v, %v", d, err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		cal        Calendar
+		str        string
+		kind       Kind
+		start, end Date
+		canonical  string
+	}{
+		{Calendar{}, "2026-03", Month, NewDate(2026, 3, 1), NewDate(2026, 3, 31), "2026-03"},
+		{Calendar{}, "2024-02", Month, NewDate(2024, 2, 1), NewDate(2024, 2, 29), "2024-02"},
+		{Calendar{}, "2026-Q1", Quarter, NewDate(2026, 1, 1), NewDate(2026, 3, 31), "2026-Q1"},
+		{Calendar{}, "2026-Q4", Quarter, NewDate(2026, 10, 1), NewDate(2026, 12, 31), "2026-Q4"},
+		{Calendar{}, "2026", Year, NewDate(2026, 1, 1), NewDate(2026, 12, 31), "2026"},
+		{Calendar{}, "FY2026", Year, NewDate(2026, 1, 1), NewDate(2026, 12, 31), "2026"},
+		{Calendar{Start: time.April}, "FY2026", Year, NewDate(2025, 4, 1), NewDate(2026, 3, 31), "FY2026/04"},
+		{Calendar{Start: time.April}, "FY2026-Q1", Quarter, NewDate(2025, 4, 1), NewDate(2025, 6, 30), "FY2026-Q1/04"},
+		{Calendar{Start: time.April}, "FY2026-Q4", Quarter, NewDate(2026, 1, 1), NewDate(2026, 3, 31), "FY2026-Q4/04"},
+		{Calendar{Start: time.April}, "2026", Year, NewDate(2026, 1, 1), NewDate(2026, 12, 31), "2026"},
+		{Calendar{}, "FY2026/10", Year, NewDate(2025, 10, 1), NewDate(2026, 9, 30), "FY2026/10"},
+		{Calendar{Start: time.April}, "FY2026-Q2/10", Quarter, NewDate(2026, 1, 1), NewDate(2026, 3, 31), "FY2026-Q2/10"},
+	}
+
+	for _, test := range tests {
+		p, err := test.cal.Parse(test.str)
+		if err != nil {
+			t.Errorf("%s: %v", test.str, err)
+			continue
+		}
+
+		if p.Kind() != test.kind || p.Start() != test.start || p.End() != test.end {
+			t.Errorf("%s: expected %v from %v to %v, got %v from %v to %v",
+				test.str, test.kind, test.start, test.end, p.Kind(), p.Start(), p.End())
+		}
+
+		if p.String() != test.canonical {
+			t.Errorf("%s: expected %s, got %s", test.str, test.canonical, p.String())
+		}
+
+		if r, err := Parse(p.String()); err != nil || r != p {
+			t.Errorf("%s: %s does not parse back to itself, got %v, %v", test.str, p, r, err)
+		}
+	}
+
+	bad := []string{"26", "2026-3", "2026-13", "2026-Q0", "2026-Q5", "2026-Q", "FY2026-03", "2026/04", "FY2026/13", "FY2026/4", "fy2026", " 2026"}
+	for _, str := range bad {
+		if _, err := Parse(str); err == nil {
+			t.Errorf("%q should not parse", str)
+		}
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	cal := Calendar{Start: time.July}
+	d := NewDate(2026, time.February, 14)
+
+	tests := []struct {
+		p   Period
+		str string
+		day Date
+	}{
+		{cal.Month(d), "2026-02", d},
+		{cal.Quarter(d), "FY2026-Q3/07", d},
+		{cal.Year(d), "FY2026/07", d},
+		{Calendar{}.Quarter(d), "2026-Q1", d},
+		{Calendar{}.Year(d), "2026", d},
+		{cal.Year(NewDate(2026, time.July, 1)), "FY2027/07", NewDate(2027, time.June, 30)},
+		{cal.Year(NewDate(2026, time.June, 30)), "FY2026/07", NewDate(2025, time.July, 1)},
+	}
+
+	for _, test := range tests {
+		if test.p.String() != test.str {
+			t.Errorf("expected %s, got %s", test.str, test.p)
+		}
+
+		if !test.p.Contains(test.day) {
+			t.Errorf("%s should contain %v", test.p, test.day)
+		}
+	}
+
+	q := cal.Quarter(d)
+	if q.Contains(q.Start().AddDays(-1)) || q.Contains(q.End().AddDays(1)) || !q.Contains(q.End()) {
+		t.Errorf("%s has wrong bounds", q)
+	}
+
+	if q.Len() != 90 {
+		t.Errorf("%s should have 90 days, got %d", q, q.Len())
+	}
+
+	if q.Next().String() != "FY2026-Q4/07" || q.Prev().String() != "FY2026-Q2/07" || q.Add(2).String() != "FY2027-Q1/07" {
+		t.Errorf("unexpected neighbours of %s: %s, %s, %s", q, q.Next(), q.Prev(), q.Add(2))
+	}
+}
+
+func TestIteration(t *testing.T) {
+	fy, _ := Calendar{Start: time.April}.Parse("FY2026")
+
+	var quarters []string
+	for q := range fy.Periods(Quarter) {
+		quarters = append(quarters, q.String())
+	}
+
+	if !slices.Equal(quarters, []string{"FY2026-Q1/04", "FY2026-Q2/04", "FY2026-Q3/04", "FY2026-Q4/04"}) {
+		t.Errorf("unexpected quarters %v", quarters)
+	}
+
+	var months []string
+	for m := range fy.Periods(Month) {
+		months = append(months, m.String())
+	}
+
+	if len(months) != 12 || months[0] != "2025-04" || months[11] != "2026-03" {
+		t.Errorf("unexpected months %v", months)
+	}
+
+	m, _ := Parse("2026-03")
+	for range m.Periods(Year) {
+		t.Error("a month should not be made of years")
+	}
+
+	var days int
+	for d := range m.Days() {
+		if !m.Contains(d) {
+			t.Errorf("%v is not in %s", d, m)
+		}
+		days++
+	}
+
+	if days != 31 {
+		t.Errorf("March should have 31 days, got %d", days)
+	}
+
+	from, _ := Parse("2025-11")
+	var span []string
+	for p := range Range(from, m) {
+		span = append(span, p.String())
+	}
+
+	if !slices.Equal(span, []string{"2025-11", "2025-12", "2026-01", "2026-02", "2026-03"}) {
+		t.Errorf("unexpected range %v", span)
+	}
+
+	for range Range(m, from) {
+		t.Error("a backwards range should be empty")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type report struct {
+		Date   Date   `json:"date"`
+		Period Period `json:"period"`
+	}
+
+	q, _ := Calendar{Start: time.April}.Parse("FY2026-Q2")
+	r := report{Date: NewDate(2025, time.August, 5), Period: q}
+
+	buf, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(buf) != `{"date":"2025-08-05","period":"FY2026-Q2/04"}` {
+		t.Errorf("unexpected encoding %s", buf)
+	}
+
+	var got report
+	if err := json.Unmarshal(buf, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got != r {
+		t.Errorf("expected %+v, got %+v", r, got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"period":"2026-13"}`), &got); err == nil {
+		t.Error("bad period should not unmarshal")
+	}
+}
@@ -0,0 +1,355 @@
+// Copyright (C) 2025 Alan Barbosa Lima.
+//
+// PRP is licensed under the GNU General Public License
+// version 3. You should have received a copy of the
+// license, located in LICENSE, at the root of the source
+// tree. If not, see <https://www.gnu.org/licenses/>.
+
+// Package pix decodes and encodes Pix "copia e cola" payloads, the
+// text behind Pix QR codes, which follows the EMV merchant presented
+// QR code format as profiled by the Brazilian Central Bank (BR Code).
+//
+// A payload is a sequence of fields made of a two digit ID, a two
+// digit length and the value, some of which nest further fields. The
+// last field is a CRC16 of everything before its value.
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// GUI identifies the Pix merchant account template among the others a
+// payload may carry.
+const GUI = "br.gov.bcb.pix"
+
+type Payload struct {
+	// Key is the Pix key of static payloads, URL is the location of
+	// the charge of dynamic ones.
+	Key string
+	URL string
+
+	Description string
+
+	// Merchant is at most 25 characters long and City 15, as per the
+	// specification, longer ones are not encoded.
+	Merchant   string
+	City       string
+	PostalCode string
+
+	// Amount is in cents, zero if the payer chooses it.
+	Amount int64
+
+	// TxID identifies the transaction to the receiver, it is empty if
+	// none was given.
+	TxID string
+
+	// Unique tells the payload may only be paid once.
+	Unique bool
+}
+
+var (
+	ErrMalformed    = errors.New("pix: malformed payload")
+	ErrBadCRC       = errors.New("pix: CRC does not match")
+	ErrBadFormat    = errors.New("pix: unsupported payload format")
+	ErrNotPix       = errors.New("pix: payload has no Pix merchant account")
+	ErrMissingField = errors.New("pix: payload lacks a mandatory field")
+	ErrBadCurrency  = errors.New("pix: currency must be the Brazilian real")
+	ErrBadAmount    = errors.New("pix: invalid amount")
+	ErrTooLong      = errors.New("pix: field longer than allowed")
+)
+
+const (
+	maxMerchant = 25
+	maxCity     = 15
+)
+
+const (
+	idFormat     = "00"
+	idInitiation = "01"
+	idCategory   = "52"
+	idCurrency   = "53"
+	idAmount     = "54"
+	idCountry    = "58"
+	idMerchant   = "59"
+	idCity       = "60"
+	idPostalCode = "61"
+	idAdditional = "62"
+	idCRC        = "63"
+
+	idGUI         = "00"
+	idKey         = "01"
+	idDescription = "02"
+	idURL         = "25"
+
+	idTxID = "05"
+
+	currencyBRL = "986"
+	noTxID      = "***"
+)
+
+type field struct {
+	id    string
+	value string
+}
+
+// Parse decodes a payload, checking its CRC and the fields Pix
+// requires. Leading and trailing spaces are ignored.
+func Parse(str string) (Payload, error) {
+	str = strings.TrimSpace(str)
+
+	n := len(str)
+	if n < 8 || str[n-8:n-4] != idCRC+"04" {
+		return Payload{}, ErrMalformed
+	}
+
+	crc, err := strconv.ParseUint(str[n-4:], 16, 16)
+	if err != nil {
+		return Payload{}, ErrMalformed
+	}
+
+	if uint16(crc) != CRC16(str[:n-4]) {
+		return Payload{}, ErrBadCRC
+	}
+
+	fields, err := split(str[:n-8])
+	if err != nil {
+		return Payload{}, err
+	}
+
+	if len(fields) == 0 || fields[0].id != idFormat || fields[0].value != "01" {
+		return Payload{}, ErrBadFormat
+	}
+
+	var p Payload
+	var found, hasCurrency, hasMerchant, hasCity bool
+
+	for _, f := range fields {
+		switch f.id {
+		case idInitiation:
+			p.Unique = f.value == "12"
+
+		case idCurrency:
+			if f.value != currencyBRL {
+				return Payload{}, ErrBadCurrency
+			}
+			hasCurrency = true
+
+		case idAmount:
+			if p.Amount, err = parseAmount(f.value); err != nil {
+				return Payload{}, err
+			}
+
+		case idMerchant:
+			p.Merchant, hasMerchant = f.value, true
+
+		case idCity:
+			p.City, hasCity = f.value, true
+
+		case idPostalCode:
+			p.PostalCode = f.value
+
+		case idAdditional:
+			sub, err := split(f.value)
+			if err != nil {
+				return Payload{}, err
+			}
+
+			if txid := lookup(sub, idTxID); txid != noTxID {
+				p.TxID = txid
+			}
+
+		default:
+			if found || f.id < "26" || f.id > "51" {
+				continue
+			}
+
+			sub, err := split(f.value)
+			if err != nil {
+				return Payload{}, err
+			}
+
+			if !strings.EqualFold(lookup(sub, idGUI), GUI) {
+				continue
+			}
+
+			p.Key = lookup(sub, idKey)
+			p.Description = lookup(sub, idDescription)
+			p.URL = lookup(sub, idURL)
+			found = true
+		}
+	}
+
+	if !found || p.Key == "" && p.URL == "" {
+		return Payload{}, ErrNotPix
+	}
+
+	if !hasCurrency || !hasMerchant || !hasCity {
+		return Payload{}, ErrMissingField
+	}
+
+	return p, nil
+}
+
+// Encode encodes the payload, with the Pix merchant account in field
+// 26. Fields are limited to 99 characters, the merchant name and city
+// to less.
+func (p *Payload) Encode() (string, error) {
+	if p.Key == "" && p.URL == "" {
+		return "", ErrNotPix
+	}
+
+	if p.Amount < 0 {
+		return "", ErrBadAmount
+	}
+
+	if p.Merchant == "" || p.City == "" {
+		return "", ErrMissingField
+	}
+
+	if utf8.RuneCountInString(p.Merchant) > maxMerchant || utf8.RuneCountInString(p.City) > maxCity {
+		return "", ErrTooLong
+	}
+
+	initiation := ""
+	if p.Unique {
+		initiation = "12"
+	}
+
+	var account, additional strings.Builder
+	var b strings.Builder
+
+	err := errors.Join(
+		write(&account, idGUI, GUI),
+		write(&account, idKey, p.Key),
+		write(&account, idDescription, p.Description),
+		write(&account, idURL, p.URL),
+
+		write(&additional, idTxID, or(p.TxID, noTxID)),
+
+		write(&b, idFormat, "01"),
+		write(&b, idInitiation, initiation),
+		write(&b, "26", account.String()),
+		write(&b, idCategory, "0000"),
+		write(&b, idCurrency, currencyBRL),
+		write(&b, idAmount, formatAmount(p.Amount)),
+		write(&b, idCountry, "BR"),
+		write(&b, idMerchant, p.Merchant),
+		write(&b, idCity, p.City),
+		write(&b, idPostalCode, p.PostalCode),
+		write(&b, idAdditional, additional.String()),
+	)
+	if err != nil {
+		return "", ErrTooLong
+	}
+
+	b.WriteString(idCRC + "04")
+	return fmt.Sprintf("%s%04X", b.String(), CRC16(b.String())), nil
+}
+
+// CRC16 is the CRC-16/CCITT-FALSE checksum of the string, polynomial
+// 0x1021 starting from 0xFFFF.
+func CRC16(str string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(str); i++ {
+		crc ^= uint16(str[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+func split(str string) ([]field, error) {
+	var fields []field
+
+	for len(str) > 0 {
+		if len(str) < 4 || !isDigits(str[:4]) {
+			return nil, ErrMalformed
+		}
+
+		n, _ := strconv.Atoi(str[2:4])
+		if len(str) < 4+n {
+			return nil, ErrMalformed
+		}
+
+		fields = append(fields, field{id: str[:2], value: str[4 : 4+n]})
+		str = str[4+n:]
+	}
+
+	return fields, nil
+}
+
+func lookup(fields []field, id string) string {
+	for _, f := range fields {
+		if f.id == id {
+			return f.value
+		}
+	}
+
+	return ""
+}
+
+// write appends the field, empty values are left out.
+func write(b *strings.Builder, id, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if len(value) > 99 {
+		return ErrTooLong
+	}
+
+	fmt.Fprintf(b, "%s%02d%s", id, len(value), value)
+	return nil
+}
+
+func parseAmount(str string) (int64, error) {
+	whole, frac, dot := strings.Cut(str, ".")
+	if whole == "" || dot && frac == "" || !isDigits(whole) || !isDigits(frac) || len(frac) > 2 {
+		return 0, ErrBadAmount
+	}
+
+	frac += strings.Repeat("0", 2-len(frac))
+
+	amount, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, ErrBadAmount
+	}
+
+	return amount, nil
+}
+
+func formatAmount(amount int64) string {
+	if amount == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
+
+func isDigits(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] < '0' || str[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func or(str, def string) string {
+	if str == "" {
+		return def
+	}
+
+	return str
+}
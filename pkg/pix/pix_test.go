@@ -0,0 +1,183 @@
+package pix_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/alan-b-lima/prp/pkg/pix"
+)
+
+// example is the static payload given in the BR Code manual.
+const example = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func TestParse(t *testing.T) {
+	p, err := Parse(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Payload{
+		Key:      "123e4567-e12b-12d1-a456-426655440000",
+		Merchant: "Fulano de Tal",
+		City:     "BRASILIA",
+	}
+	if p != want {
+		t.Errorf("expected %+v, got %+v", want, p)
+	}
+
+	if _, err := Parse(strings.ToLower(example[:len(example)-4]) + "1d3d"); err != ErrBadCRC {
+		t.Errorf("expected %v, got %v", ErrBadCRC, err)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	p := Payload{
+		Key:      "123e4567-e12b-12d1-a456-426655440000",
+		Merchant: "Fulano de Tal",
+		City:     "BRASILIA",
+	}
+
+	str, err := p.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if str != example {
+		t.Errorf("expected %s, got %s", example, str)
+	}
+
+	tests := []Payload{
+		{Key: "fulano@example.com", Description: "Aluguel", Merchant: "Fulano", City: "SAO PAULO", Amount: 123456, TxID: "ALUGUEL0326"},
+		{URL: "pix.example.com/qr/v2/9d36b84f", Merchant: "Loja", City: "RECIFE", PostalCode: "50000000", Amount: 5, Unique: true},
+		{Key: "+5561999999999", Merchant: "Ciclano", City: "BRASILIA", Amount: 1000},
+	}
+
+	for _, test := range tests {
+		str, err := test.Encode()
+		if err != nil {
+			t.Errorf("%+v: %v", test, err)
+			continue
+		}
+
+		p, err := Parse(str)
+		if err != nil {
+			t.Errorf("%s: %v", str, err)
+			continue
+		}
+
+		if p != test {
+			t.Errorf("%s should decode to %+v, got %+v", str, test, p)
+		}
+	}
+
+	long := Payload{Key: "k", Merchant: "m", City: "c", Description: strings.Repeat("x", 100)}
+	if _, err := long.Encode(); err != ErrTooLong {
+		t.Errorf("expected %v, got %v", ErrTooLong, err)
+	}
+}
+
+func TestEncodeLimits(t *testing.T) {
+	tests := []struct {
+		char           string
+		merchant, city int
+		err            error
+	}{
+		{"m", 25, 15, nil},
+		{"m", 26, 15, ErrTooLong},
+		{"m", 25, 16, ErrTooLong},
+
+		// limits are in characters, not bytes
+		{"ã", 25, 15, nil},
+		{"ã", 26, 15, ErrTooLong},
+		{"ã", 25, 16, ErrTooLong},
+	}
+
+	for _, test := range tests {
+		p := Payload{Key: "k", Merchant: strings.Repeat(test.char, test.merchant), City: strings.Repeat(test.char, test.city)}
+
+		str, err := p.Encode()
+		if err != test.err {
+			t.Errorf("merchant of %d and city of %d characters: expected %v, got %v", test.merchant, test.city, test.err, err)
+			continue
+		}
+
+		if err == nil {
+			if got, err := Parse(str); err != nil || got != p {
+				t.Errorf("%s should decode to %+v, got %+v, %v", str, p, got, err)
+			}
+		}
+	}
+
+	p := Payload{Key: "k", Merchant: "José Conceição", City: "São José"}
+	if _, err := p.Encode(); err != nil {
+		t.Errorf("accented names within the limits should be encoded, got %v", err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	account := field("26", field("00", GUI)+field("01", "k"))
+
+	tests := []struct {
+		str string
+		err error
+	}{
+		{"", ErrMalformed},
+		{example[:len(example)-1] + "E", ErrBadCRC},
+		{example[:20] + "X" + example[21:], ErrBadCRC},
+		{withCRC("000201265800"), ErrMalformed},
+		{withCRC("00020126X"), ErrMalformed},
+		{withCRC("000202"), ErrBadFormat},
+		{withCRC("5303986000201"), ErrBadFormat},
+		{withCRC("000201" + field("26", field("00", GUI)) + "5303986"), ErrNotPix},
+		{withCRC("000201" + field("26", field("00", "com.example.pix")+field("01", "k")) + "5303986"), ErrNotPix},
+		{withCRC("000201" + account + "5303840"), ErrBadCurrency},
+		{withCRC("000201" + account + "5303986" + field("54", "0,5")), ErrBadAmount},
+		{withCRC("000201" + account + "5303986" + field("54", "1.555")), ErrBadAmount},
+		{withCRC("000201" + account + "5303986" + field("54", ".5")), ErrBadAmount},
+		{withCRC("000201" + account + "5303986" + field("54", "5.")), ErrBadAmount},
+		{withCRC("000201" + account + "5303986" + field("59", "m")), ErrMissingField},
+	}
+
+	for _, test := range tests {
+		if _, err := Parse(test.str); err != test.err {
+			t.Errorf("%s: expected %v, got %v", test.str, test.err, err)
+		}
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		amount string
+		cents  int64
+	}{
+		{"10", 1000},
+		{"10.5", 1050},
+		{"10.50", 1050},
+		{"0.01", 1},
+	}
+
+	for _, test := range tests {
+		str := "000201" + field("26", field("00", GUI)+field("01", "k")) + "5303986" +
+			field("54", test.amount) + field("59", "m") + field("60", "c")
+
+		p, err := Parse(withCRC(str))
+		if err != nil {
+			t.Errorf("%s: %v", test.amount, err)
+			continue
+		}
+
+		if p.Amount != test.cents {
+			t.Errorf("%s should be %d cents, got %d", test.amount, test.cents, p.Amount)
+		}
+	}
+}
+
+func field(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+func withCRC(str string) string {
+	str += "6304"
+	return fmt.Sprintf("%s%04X", str, CRC16(str))
+}
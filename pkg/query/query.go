@@ -0,0 +1,599 @@
+// Copyright (C) 2025 Alan Barbosa Lima.
+//
+// PRP is licensed under the GNU General Public License
+// version 3. You should have received a copy of the
+// license, located in LICENSE, at the root of the source
+// tree. If not, see <https://www.gnu.org/licenses/>.
+
+// Package query parses the small search language used to filter
+// records, such as
+//
+//	payee:uber amount:>50 date:2026-01..2026-03 -account:expenses:food
+//
+// into a syntax tree. Terms separated by spaces must all hold, OR
+// between them requires only one of its sides to, and a leading minus
+// negates a term or a group. Parentheses group, otherwise negation
+// binds tighter than juxtaposition, which binds tighter than OR, so
+//
+//	tag:trip (payee:uber OR payee:99) -(amount:<10 OR memo:tip)
+//
+// are three conditions that must all hold. A term is an optional field
+// name followed by a colon and a value. Values may be quoted to hold
+// spaces, parentheses or be the word OR, and those of fields may be
+// prefixed by a comparison operator or be a range with either bound
+// left open.
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Op int
+
+const (
+	Match        Op = iota // field:value
+	Equal                  // field:=value
+	Less                   // field:<value
+	LessEqual              // field:<=value
+	Greater                // field:>value
+	GreaterEqual           // field:>=value
+	Range                  // field:from..to
+)
+
+var opStrings = [...]string{
+	Match:        "",
+	Equal:        "=",
+	Less:         "<",
+	LessEqual:    "<=",
+	Greater:      ">",
+	GreaterEqual: ">=",
+	Range:        "..",
+}
+
+func (o Op) String() string {
+	return opStrings[o]
+}
+
+// Node is a node of the syntax tree, one of *And, *Or, *Not, *Group
+// and *Term.
+type Node interface {
+	String() string
+	write(b *strings.Builder)
+}
+
+// And holds if all of its nodes hold, they are at least two.
+type And struct {
+	Nodes []Node
+}
+
+// Or holds if any of its nodes holds, they are at least two.
+type Or struct {
+	Nodes []Node
+}
+
+// Not holds if its node does not, Pos is that of the minus.
+type Not struct {
+	Pos  int
+	Node Node
+}
+
+// Group is a parenthesized node, Pos is that of the opening
+// parenthesis.
+type Group struct {
+	Pos  int
+	Node Node
+}
+
+// Term is a single condition. Field is empty for free text. For
+// ranges, Value is the lower bound and To the upper one, either may be
+// empty for an open bound.
+type Term struct {
+	Pos   int
+	Field string
+	Op    Op
+	Value string
+	To    string
+}
+
+// Query is a parsed query, Root is nil for the empty query.
+type Query struct {
+	Root Node
+}
+
+// Walk calls fn for the node and, as long as fn returns true, for its
+// descendants, depth first.
+func Walk(n Node, fn func(Node) bool) {
+	if n == nil || !fn(n) {
+		return
+	}
+
+	switch n := n.(type) {
+	case *And:
+		for _, c := range n.Nodes {
+			Walk(c, fn)
+		}
+	case *Or:
+		for _, c := range n.Nodes {
+			Walk(c, fn)
+		}
+	case *Not:
+		Walk(n.Node, fn)
+	case *Group:
+		Walk(n.Node, fn)
+	}
+}
+
+// Eval tells whether the node holds, given whether each of its terms
+// does. Terms are evaluated lazily, from left to right.
+func Eval(n Node, term func(*Term) bool) bool {
+	switch n := n.(type) {
+	case *And:
+		for _, c := range n.Nodes {
+			if !Eval(c, term) {
+				return false
+			}
+		}
+		return true
+	case *Or:
+		for _, c := range n.Nodes {
+			if Eval(c, term) {
+				return true
+			}
+		}
+		return false
+	case *Not:
+		return !Eval(n.Node, term)
+	case *Group:
+		return Eval(n.Node, term)
+	case *Term:
+		return term(n)
+	}
+
+	return true
+}
+
+// Match tells whether the query holds, the empty query always does.
+func (q *Query) Match(term func(*Term) bool) bool {
+	return q.Root == nil || Eval(q.Root, term)
+}
+
+// Fields is the set of field names used in the query, in order of
+// appearance.
+func (q *Query) Fields() []string {
+	var fields []string
+	Walk(q.Root, func(n Node) bool {
+		if t, ok := n.(*Term); ok && t.Field != "" && !slices.Contains(fields, t.Field) {
+			fields = append(fields, t.Field)
+		}
+		return true
+	})
+
+	return fields
+}
+
+// String renders the query in canonical form, which parses back to
+// the same tree.
+func (q *Query) String() string {
+	if q.Root == nil {
+		return ""
+	}
+
+	return q.Root.String()
+}
+
+func (n *And) String() string   { return render(n) }
+func (n *Or) String() string    { return render(n) }
+func (n *Not) String() string   { return render(n) }
+func (n *Group) String() string { return render(n) }
+func (t *Term) String() string  { return render(t) }
+
+func render(n Node) string {
+	var b strings.Builder
+	n.write(&b)
+	return b.String()
+}
+
+func (n *And) write(b *strings.Builder) { join(b, n.Nodes, " ") }
+func (n *Or) write(b *strings.Builder)  { join(b, n.Nodes, " OR ") }
+
+func join(b *strings.Builder, nodes []Node, sep string) {
+	for i, n := range nodes {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+
+		n.write(b)
+	}
+}
+
+func (n *Not) write(b *strings.Builder) {
+	b.WriteByte('-')
+	n.Node.write(b)
+}
+
+func (n *Group) write(b *strings.Builder) {
+	b.WriteByte('(')
+	n.Node.write(b)
+	b.WriteByte(')')
+}
+
+func (t *Term) write(b *strings.Builder) {
+	if t.Field != "" {
+		b.WriteString(t.Field)
+		b.WriteByte(':')
+	}
+
+	switch t.Op {
+	case Match:
+		b.WriteString(quote(t.Value, t.Field == ""))
+	case Range:
+		b.WriteString(t.Value)
+		b.WriteString("..")
+		b.WriteString(t.To)
+	default:
+		b.WriteString(t.Op.String())
+		b.WriteString(quote(t.Value, false))
+	}
+}
+
+// SyntaxError reports where and why a query could not be parsed, Pos
+// is a byte offset into the query.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("query: column %d: %s", e.Pos+1, e.Msg)
+}
+
+// Parse parses a query, field names are case insensitive and returned
+// in lower case. The empty query has no root and matches everything.
+func Parse(src string) (Query, error) {
+	p := parser{src: src}
+
+	p.skipSpace()
+	if p.eof() {
+		return Query{}, nil
+	}
+
+	root, err := p.or()
+	if err != nil {
+		return Query{}, err
+	}
+
+	// or stops either at the end or at an unmatched parenthesis
+	if !p.eof() {
+		return Query{}, p.errorf(p.pos, "unexpected )")
+	}
+
+	return Query{Root: root}, nil
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) eof() bool { return p.pos >= len(p.src) }
+
+func (p *parser) peek() byte { return p.src[p.pos] }
+
+func (p *parser) skipSpace() {
+	for !p.eof() && isSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(pos int, format string, args ...any) error {
+	return &SyntaxError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) or() (Node, error) {
+	n, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []Node{n}
+	for p.isOr() {
+		pos := p.pos
+		p.pos += len("OR")
+
+		p.skipSpace()
+		if p.eof() || p.peek() == ')' || p.isOr() {
+			return nil, p.errorf(pos, "missing term after OR")
+		}
+
+		n, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, n)
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+
+	return &Or{Nodes: nodes}, nil
+}
+
+func (p *parser) and() (Node, error) {
+	var nodes []Node
+	for {
+		p.skipSpace()
+		if p.eof() || p.peek() == ')' || p.isOr() {
+			break
+		}
+
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, n)
+	}
+
+	switch {
+	case len(nodes) == 1:
+		return nodes[0], nil
+	case len(nodes) > 1:
+		return &And{Nodes: nodes}, nil
+	case p.isOr():
+		return nil, p.errorf(p.pos, "missing term before OR")
+	}
+
+	return nil, p.errorf(p.pos, "unexpected )")
+}
+
+func (p *parser) unary() (Node, error) {
+	if p.peek() != '-' {
+		return p.primary()
+	}
+
+	pos := p.pos
+	p.pos++
+
+	if p.eof() || isSpace(p.peek()) || p.peek() == ')' {
+		return nil, p.errorf(pos, "nothing to negate after -")
+	}
+
+	n, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Not{Pos: pos, Node: n}, nil
+}
+
+func (p *parser) primary() (Node, error) {
+	if p.peek() != '(' {
+		return p.term()
+	}
+
+	pos := p.pos
+	p.pos++
+
+	p.skipSpace()
+	if !p.eof() && p.peek() == ')' {
+		return nil, p.errorf(pos, "empty group")
+	}
+
+	var n Node
+	if !p.eof() {
+		var err error
+		if n, err = p.or(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.eof() {
+		return nil, p.errorf(pos, "unclosed (")
+	}
+
+	p.pos++
+	return &Group{Pos: pos, Node: n}, nil
+}
+
+// isOr tells whether the next word is the OR operator.
+func (p *parser) isOr() bool {
+	rest, ok := strings.CutPrefix(p.src[p.pos:], "OR")
+	return ok && (rest == "" || isDelim(rest[0]))
+}
+
+func (p *parser) term() (*Term, error) {
+	t := &Term{Pos: p.pos}
+
+	if p.peek() != '"' {
+		if i := strings.IndexByte(p.word(), ':'); i >= 0 {
+			if i == 0 {
+				return nil, p.errorf(p.pos, "missing field name before :")
+			}
+
+			t.Field = strings.ToLower(p.src[p.pos : p.pos+i])
+			if j := strings.IndexFunc(t.Field, isNotField); j >= 0 {
+				return nil, p.errorf(p.pos+j, "invalid character %q in field name", t.Field[j])
+			}
+
+			p.pos += i + 1
+			if p.eof() || isDelim(p.peek()) {
+				return nil, p.errorf(p.pos-1, "missing value for field %s", t.Field)
+			}
+
+			return t, p.fieldValue(t)
+		}
+	}
+
+	val, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	t.Value = val
+	return t, nil
+}
+
+func (p *parser) fieldValue(t *Term) error {
+	start := p.pos
+
+	for _, op := range [...]Op{LessEqual, GreaterEqual, Less, Greater, Equal} {
+		if strings.HasPrefix(p.src[p.pos:], op.String()) {
+			t.Op = op
+			p.pos += len(op.String())
+			break
+		}
+	}
+
+	if p.eof() || isDelim(p.peek()) {
+		return p.errorf(start, "missing value after %s", t.Op)
+	}
+
+	if p.peek() == '"' {
+		val, err := p.value()
+		if err != nil {
+			return err
+		}
+
+		t.Value = val
+		return nil
+	}
+
+	word := p.word()
+	from, to, isRange := strings.Cut(word, "..")
+	if !isRange {
+		val, err := p.value()
+		if err != nil {
+			return err
+		}
+
+		t.Value = val
+		return nil
+	}
+
+	if t.Op != Match {
+		return p.errorf(start, "operator %s cannot be applied to a range", t.Op)
+	}
+
+	if from == "" && to == "" {
+		return p.errorf(p.pos, "range needs at least one bound")
+	}
+
+	if strings.Contains(to, "..") {
+		return p.errorf(p.pos+len(from)+2+strings.Index(to, ".."), "range has more than two bounds")
+	}
+
+	if i := strings.IndexByte(word, '"'); i >= 0 {
+		return p.errorf(p.pos+i, "unexpected quote inside range")
+	}
+
+	t.Op, t.Value, t.To = Range, from, to
+	p.pos += len(word)
+	return nil
+}
+
+// word is the run of characters up to the next space or parenthesis,
+// quotes are not taken into account.
+func (p *parser) word() string {
+	end := p.pos
+	for end < len(p.src) && !isDelim(p.src[end]) {
+		end++
+	}
+
+	return p.src[p.pos:end]
+}
+
+func (p *parser) value() (string, error) {
+	if p.peek() != '"' {
+		word := p.word()
+		if i := strings.IndexByte(word, '"'); i >= 0 {
+			return "", p.errorf(p.pos+i, "unexpected quote inside value")
+		}
+
+		p.pos += len(word)
+		return word, nil
+	}
+
+	start := p.pos
+	p.pos++
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf(start, "unterminated quoted value")
+		}
+
+		c := p.peek()
+		p.pos++
+
+		if c == '"' {
+			break
+		}
+
+		if c == '\\' {
+			if p.eof() {
+				return "", p.errorf(start, "unterminated quoted value")
+			}
+
+			c = p.peek()
+			if c != '"' && c != '\\' {
+				return "", p.errorf(p.pos-1, "invalid escape \\%c", c)
+			}
+			p.pos++
+		}
+
+		b.WriteByte(c)
+	}
+
+	if !p.eof() && !isDelim(p.peek()) {
+		return "", p.errorf(p.pos, "missing space after quoted value")
+	}
+
+	return b.String(), nil
+}
+
+// quote quotes the value if it would not otherwise parse back to
+// itself.
+func quote(val string, text bool) string {
+	var needs bool
+	switch {
+	case val == "", strings.ContainsAny(val, " \t\n\r\"\\()"):
+		needs = true
+	case text:
+		needs = val[0] == '-' || val == "OR" || strings.ContainsRune(val, ':')
+	default:
+		needs = strings.IndexByte("<>=", val[0]) >= 0 || strings.Contains(val, "..")
+	}
+
+	if !needs {
+		return val
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(val); i++ {
+		if val[i] == '"' || val[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(val[i])
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// isDelim tells whether the character ends a word.
+func isDelim(c byte) bool {
+	return isSpace(c) || c == '(' || c == ')'
+}
+
+func isNotField(r rune) bool {
+	return !('a' <= r && r <= 'z' || '0' <= r && r <= '9' || r == '_' || r == '.')
+}
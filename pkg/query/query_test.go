@@ -0,0 +1,232 @@
+package query_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/alan-b-lima/prp/pkg/query"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		src  string
+		root Node
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"uber", &Term{Pos: 0, Value: "uber"}},
+		{"payee:Uber", &Term{Pos: 0, Field: "payee", Value: "Uber"}},
+		{"Payee:uber", &Term{Pos: 0, Field: "payee", Value: "uber"}},
+		{"amount:>50", &Term{Pos: 0, Field: "amount", Op: Greater, Value: "50"}},
+		{"amount:>=50", &Term{Pos: 0, Field: "amount", Op: GreaterEqual, Value: "50"}},
+		{"amount:<50", &Term{Pos: 0, Field: "amount", Op: Less, Value: "50"}},
+		{"amount:<=50", &Term{Pos: 0, Field: "amount", Op: LessEqual, Value: "50"}},
+		{"amount:=50", &Term{Pos: 0, Field: "amount", Op: Equal, Value: "50"}},
+		{"date:2026-01..2026-03", &Term{Pos: 0, Field: "date", Op: Range, Value: "2026-01", To: "2026-03"}},
+		{"date:2026-01..", &Term{Pos: 0, Field: "date", Op: Range, Value: "2026-01"}},
+		{"date:..2026-03", &Term{Pos: 0, Field: "date", Op: Range, To: "2026-03"}},
+		{"-account:expenses:food", &Not{Pos: 0, Node: &Term{Pos: 1, Field: "account", Value: "expenses:food"}}},
+		{`payee:"padaria do ze"`, &Term{Pos: 0, Field: "payee", Value: "padaria do ze"}},
+		{`"a \"quoted\" \\ text"`, &Term{Pos: 0, Value: `a "quoted" \ text`}},
+		{`"key:value"`, &Term{Pos: 0, Value: "key:value"}},
+		{`memo:""`, &Term{Pos: 0, Field: "memo"}},
+		{`"OR"`, &Term{Pos: 0, Value: "OR"}},
+		{"or", &Term{Pos: 0, Value: "or"}},
+		{"ORDER", &Term{Pos: 0, Value: "ORDER"}},
+		{
+			"payee:uber amount:>50 date:2026-01..2026-03 tag:trip -account:expenses:food",
+			&And{Nodes: []Node{
+				&Term{Pos: 0, Field: "payee", Value: "uber"},
+				&Term{Pos: 11, Field: "amount", Op: Greater, Value: "50"},
+				&Term{Pos: 22, Field: "date", Op: Range, Value: "2026-01", To: "2026-03"},
+				&Term{Pos: 44, Field: "tag", Value: "trip"},
+				&Not{Pos: 53, Node: &Term{Pos: 54, Field: "account", Value: "expenses:food"}},
+			}},
+		},
+		{
+			"a b OR c",
+			&Or{Nodes: []Node{
+				&And{Nodes: []Node{&Term{Pos: 0, Value: "a"}, &Term{Pos: 2, Value: "b"}}},
+				&Term{Pos: 7, Value: "c"},
+			}},
+		},
+		{
+			"a OR b OR c",
+			&Or{Nodes: []Node{&Term{Pos: 0, Value: "a"}, &Term{Pos: 5, Value: "b"}, &Term{Pos: 10, Value: "c"}}},
+		},
+		{
+			"a (b OR c)",
+			&And{Nodes: []Node{
+				&Term{Pos: 0, Value: "a"},
+				&Group{Pos: 2, Node: &Or{Nodes: []Node{&Term{Pos: 3, Value: "b"}, &Term{Pos: 8, Value: "c"}}}},
+			}},
+		},
+		{
+			"-(payee:uber OR payee:99)",
+			&Not{Pos: 0, Node: &Group{Pos: 1, Node: &Or{Nodes: []Node{
+				&Term{Pos: 2, Field: "payee", Value: "uber"},
+				&Term{Pos: 16, Field: "payee", Value: "99"},
+			}}}},
+		},
+		{
+			`(tag:"a b")(memo:x)`,
+			&And{Nodes: []Node{
+				&Group{Pos: 0, Node: &Term{Pos: 1, Field: "tag", Value: "a b"}},
+				&Group{Pos: 11, Node: &Term{Pos: 12, Field: "memo", Value: "x"}},
+			}},
+		},
+		{"((a))", &Group{Pos: 0, Node: &Group{Pos: 1, Node: &Term{Pos: 2, Value: "a"}}}},
+	}
+
+	for _, test := range tests {
+		q, err := Parse(test.src)
+		if err != nil {
+			t.Errorf("%q: %v", test.src, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(q.Root, test.root) {
+			t.Errorf("%q should parse to %v, got %v", test.src, test.root, q.Root)
+		}
+	}
+}
+
+func TestSyntaxError(t *testing.T) {
+	tests := []struct {
+		src string
+		pos int
+	}{
+		{"-", 0},
+		{"uber - x", 5},
+		{":uber", 0},
+		{"pa$yee:uber", 2},
+		{"payee:", 5},
+		{"amount:>", 7},
+		{"amount:>=  50", 7},
+		{"date:..", 5},
+		{"date:1..2..3", 9},
+		{"amount:>1..2", 7},
+		{`payee:"uber`, 6},
+		{`payee:"uber\`, 6},
+		{`"a\nb"`, 2},
+		{`"uber"eats`, 6},
+		{`ub"er`, 2},
+		{`date:"1..2`, 5},
+		{"(", 0},
+		{"a (b", 2},
+		{"()", 0},
+		{"a )", 2},
+		{"a) b", 1},
+		{"OR a", 0},
+		{"a OR", 2},
+		{"a OR OR b", 2},
+		{"(a OR)", 3},
+		{"-)", 0},
+		{"-(a", 1},
+		{"payee:)", 5},
+		{"amount:>(", 7},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(test.src)
+
+		var serr *SyntaxError
+		if !errors.As(err, &serr) {
+			t.Errorf("%q: expected a syntax error, got %v", test.src, err)
+			continue
+		}
+
+		if serr.Pos != test.pos {
+			t.Errorf("%q: expected error at %d, got %d (%v)", test.src, test.pos, serr.Pos, serr)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []string{
+		"uber",
+		"payee:uber amount:>50 date:2026-01..2026-03 tag:trip -account:expenses:food",
+		`payee:"padaria do ze"`,
+		`"a \"quoted\" \\ text"`,
+		`"-not negated"`,
+		`"key:value"`,
+		`memo:""`,
+		`memo:">5"`,
+		`memo:"1..2"`,
+		"amount:<=-5",
+		"date:..2026-03",
+		`"OR" "(x)" memo:"a)"`,
+		"tag:trip (payee:uber OR payee:99) -(amount:<10 OR memo:tip)",
+		"a b OR -c (d OR (e f))",
+	}
+
+	for _, src := range tests {
+		q, err := Parse(src)
+		if err != nil {
+			t.Errorf("%q: %v", src, err)
+			continue
+		}
+
+		if s := q.String(); s != src {
+			t.Errorf("%q should render back to itself, got %q", src, s)
+		}
+
+		r, err := Parse(q.String())
+		if err != nil {
+			t.Errorf("%q: %v", q.String(), err)
+			continue
+		}
+
+		if !reflect.DeepEqual(q, r) {
+			t.Errorf("%q does not parse back to the same tree", src)
+		}
+	}
+
+	q, _ := Parse("  PAYEE:uber   amount:>50  OR( a  b ) ")
+	if s := q.String(); s != "payee:uber amount:>50 OR (a b)" {
+		t.Errorf("unexpected canonical form %q", s)
+	}
+}
+
+func TestFields(t *testing.T) {
+	q, err := Parse("payee:uber uber (tag:a OR -tag:b) amount:>5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fields := q.Fields(); !reflect.DeepEqual(fields, []string{"payee", "tag", "amount"}) {
+		t.Errorf("unexpected fields %v", fields)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tags := map[string]bool{"trip": true, "food": true}
+
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{"", true},
+		{"tag:trip", true},
+		{"tag:work", false},
+		{"tag:trip tag:work", false},
+		{"tag:trip OR tag:work", true},
+		{"tag:work OR tag:home", false},
+		{"-tag:work", true},
+		{"-(tag:trip OR tag:work)", false},
+		{"tag:work tag:home OR tag:food", true},
+		{"tag:work (tag:home OR tag:food)", false},
+	}
+
+	for _, test := range tests {
+		q, err := Parse(test.src)
+		if err != nil {
+			t.Fatalf("%q: %v", test.src, err)
+		}
+
+		if got := q.Match(func(t *Term) bool { return tags[t.Value] }); got != test.want {
+			t.Errorf("%q: expected %v, got %v", test.src, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2025 Alan Barbosa Lima.
+//
+// PRP is licensed under the GNU General Public License
+// version 3. You should have received a copy of the
+// license, located in LICENSE, at the root of the source
+// tree. If not, see <https://www.gnu.org/licenses/>.
+
+// Package split implements the arithmetic of shared expenses:
+// dividing an amount into shares and settling the resulting debts
+// with few transfers. Amounts are integers in minor units, cents for
+// instance, so that shares always add up exactly to the total.
+package split
+
+import (
+	"errors"
+	"math"
+	"slices"
+)
+
+var (
+	ErrNoShares   = errors.New("split: at least one share is needed")
+	ErrNegative   = errors.New("split: amounts and percentages must not be negative")
+	ErrPercentSum = errors.New("split: percentages must add up to 100")
+	ErrExactSum   = errors.New("split: shares must add up to the total")
+	ErrUnbalanced = errors.New("split: balances must add up to zero")
+)
+
+// Equal divides the total into n shares that differ by at most one
+// unit, the first shares take the remainder.
+func Equal(total int64, n int) ([]int64, error) {
+	if n <= 0 {
+		return nil, ErrNoShares
+	}
+
+	if total < 0 {
+		return nil, ErrNegative
+	}
+
+	shares := make([]int64, n)
+	base, rem := total/int64(n), total%int64(n)
+
+	for i := range shares {
+		shares[i] = base
+		if int64(i) < rem {
+			shares[i]++
+		}
+	}
+
+	return shares, nil
+}
+
+// Percent divides the total according to the given percentages,
+// which must add up to 100. Rounding follows the largest remainder
+// method, so the shares add up exactly to the total.
+func Percent(total int64, percents []float64) ([]int64, error) {
+	if len(percents) == 0 {
+		return nil, ErrNoShares
+	}
+
+	if total < 0 {
+		return nil, ErrNegative
+	}
+
+	var sum float64
+	for _, p := range percents {
+		if !(p >= 0) {
+			return nil, ErrNegative
+		}
+
+		sum += p
+	}
+
+	if math.Abs(sum-100) > 1e-6 {
+		return nil, ErrPercentSum
+	}
+
+	shares := make([]int64, len(percents))
+	fracs := make([]float64, len(percents))
+
+	// normalised by the actual sum, which is only close to 100, so
+	// the floored shares do not add up to more than the total, what is
+	// left is counted down so that large totals cannot overflow
+	left := total
+	for i, p := range percents {
+		exact := float64(total) * p / sum
+		if exact >= float64(total) {
+			shares[i] = total
+		} else {
+			shares[i] = int64(math.Floor(exact))
+			fracs[i] = exact - math.Floor(exact)
+		}
+
+		left -= shares[i]
+	}
+
+	order := make([]int, len(percents))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(i, j int) int {
+		switch {
+		case fracs[i] > fracs[j]:
+			return -1
+		case fracs[i] < fracs[j]:
+			return 1
+		}
+
+		return 0
+	})
+
+	for k := 0; left > 0; k++ {
+		shares[order[k%len(order)]]++
+		left--
+	}
+
+	// floating point errors may still overshoot large totals, units
+	// are then taken back from the smallest remainders
+	for k := len(order) - 1; left < 0; k-- {
+		if k < 0 {
+			k = len(order) - 1
+		}
+
+		if i := order[k]; shares[i] > 0 {
+			shares[i]--
+			left++
+		}
+	}
+
+	return shares, nil
+}
+
+// Exact checks whether the given shares add up to the total.
+func Exact(total int64, shares []int64) error {
+	if len(shares) == 0 {
+		return ErrNoShares
+	}
+
+	var sum int64
+	for _, s := range shares {
+		if s < 0 {
+			return ErrNegative
+		}
+
+		sum += s
+	}
+
+	if sum != total {
+		return ErrExactSum
+	}
+
+	return nil
+}
+
+// Transfer is a payment that settles debts, From and To are indices
+// into the balances given to [Settle].
+type Transfer struct {
+	From   int
+	To     int
+	Amount int64
+}
+
+// Settle suggests transfers that bring every balance to zero. A
+// positive balance is owed money, a negative one owes money, and
+// they must add up to zero.
+//
+// Finding the least amount of transfers is NP-hard in general, so
+// Settle first pairs debtors and creditors with matching balances
+// and then repeatedly has the largest debtor pay the largest
+// creditor, which needs at most one transfer less than the amount of
+// non-zero balances.
+func Settle(balances []int64) ([]Transfer, error) {
+	var sum int64
+	for _, b := range balances {
+		sum += b
+	}
+
+	if sum != 0 {
+		return nil, ErrUnbalanced
+	}
+
+	left := slices.Clone(balances)
+	var transfers []Transfer
+
+	for i := range left {
+		if left[i] >= 0 {
+			continue
+		}
+
+		for j := range left {
+			if left[j] == -left[i] {
+				transfers = append(transfers, Transfer{From: i, To: j, Amount: left[j]})
+				left[i], left[j] = 0, 0
+				break
+			}
+		}
+	}
+
+	for {
+		debtor, creditor := -1, -1
+		for i, b := range left {
+			if b < 0 && (debtor < 0 || b < left[debtor]) {
+				debtor = i
+			}
+
+			if b > 0 && (creditor < 0 || b > left[creditor]) {
+				creditor = i
+			}
+		}
+
+		if debtor < 0 || creditor < 0 {
+			return transfers, nil
+		}
+
+		amount := min(-left[debtor], left[creditor])
+		transfers = append(transfers, Transfer{From: debtor, To: creditor, Amount: amount})
+
+		left[debtor] += amount
+		left[creditor] -= amount
+	}
+}
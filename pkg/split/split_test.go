@@ -0,0 +1,161 @@
+package split_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	. "github.com/alan-b-lima/prp/pkg/split"
+)
+
+func TestEqual(t *testing.T) {
+	shares, err := Equal(1000, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(shares, []int64{334, 333, 333}) {
+		t.Errorf("unexpected shares %v", shares)
+	}
+
+	if _, err := Equal(1000, 0); err != ErrNoShares {
+		t.Errorf("expected %v, got %v", ErrNoShares, err)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		total    int64
+		percents []float64
+		shares   []int64
+	}{
+		{1000, []float64{50, 50}, []int64{500, 500}},
+		{1000, []float64{33.33, 33.33, 33.34}, []int64{333, 333, 334}},
+		{100, []float64{100.0 / 3, 100.0 / 3, 100.0 / 3}, []int64{34, 33, 33}},
+		{999, []float64{10, 20, 70}, []int64{100, 200, 699}},
+	}
+
+	for _, test := range tests {
+		shares, err := Percent(test.total, test.percents)
+		if err != nil {
+			t.Errorf("%v: %v", test.percents, err)
+			continue
+		}
+
+		if !slices.Equal(shares, test.shares) {
+			t.Errorf("%d by %v should be %v, got %v", test.total, test.percents, test.shares, shares)
+		}
+	}
+
+	if _, err := Percent(1000, []float64{50, 40}); err != ErrPercentSum {
+		t.Errorf("expected %v, got %v", ErrPercentSum, err)
+	}
+}
+
+func TestPercentOvershoot(t *testing.T) {
+	// percentages within the tolerance but over 100 must not make the
+	// shares add up to more than the total
+	tests := []struct {
+		total    int64
+		percents []float64
+	}{
+		{1e12, []float64{50.0000005, 50.0000005}},
+		{1e12, []float64{33.3333336, 33.3333336, 33.3333336}},
+		{1e12, []float64{49.9999995, 49.9999995}},
+		{math.MaxInt64, []float64{50.0000005, 50.0000005}},
+		{math.MaxInt64, []float64{100}},
+	}
+
+	for _, test := range tests {
+		shares, err := Percent(test.total, test.percents)
+		if err != nil {
+			t.Errorf("%v: %v", test.percents, err)
+			continue
+		}
+
+		if err := Exact(test.total, shares); err != nil {
+			t.Errorf("%d by %v: %v, got %v", test.total, test.percents, err, shares)
+		}
+	}
+}
+
+func TestPercentErrors(t *testing.T) {
+	if _, err := Percent(1000, []float64{50, 40}); err != ErrPercentSum {
+		t.Errorf("expected %v, got %v", ErrPercentSum, err)
+	}
+
+	if _, err := Percent(1000, []float64{150, -50}); err != ErrNegative {
+		t.Errorf("expected %v, got %v", ErrNegative, err)
+	}
+}
+
+func TestExact(t *testing.T) {
+	if err := Exact(1000, []int64{600, 400}); err != nil {
+		t.Errorf("following error shouldn't have happened: %v", err)
+	}
+
+	if err := Exact(1000, []int64{600, 300}); err != ErrExactSum {
+		t.Errorf("expected %v, got %v", ErrExactSum, err)
+	}
+}
+
+func TestSettle(t *testing.T) {
+	transfers, err := Settle([]int64{-50, 50, -30, 10, 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(transfers) != 3 {
+		t.Errorf("expected 3 transfers, got %v", transfers)
+	}
+
+	if _, err := Settle([]int64{10, -5}); err != ErrUnbalanced {
+		t.Errorf("expected %v, got %v", ErrUnbalanced, err)
+	}
+}
+
+func TestSettleRandom(t *testing.T) {
+	for range 1000 {
+		balances := make([]int64, rand.IntN(10)+1)
+
+		var sum int64
+		for i := range balances[1:] {
+			balances[i+1] = rand.Int64N(2001) - 1000
+			sum += balances[i+1]
+		}
+		balances[0] = -sum
+
+		transfers, err := Settle(balances)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var nonzero int
+		for _, b := range balances {
+			if b != 0 {
+				nonzero++
+			}
+		}
+
+		if nonzero > 0 && len(transfers) >= nonzero {
+			t.Errorf("%v settled with %d transfers, more than needed", balances, len(transfers))
+		}
+
+		left := slices.Clone(balances)
+		for _, tr := range transfers {
+			if tr.Amount <= 0 {
+				t.Errorf("transfer %+v should be positive", tr)
+			}
+
+			left[tr.From] += tr.Amount
+			left[tr.To] -= tr.Amount
+		}
+
+		for i, b := range left {
+			if b != 0 {
+				t.Errorf("%v: balance %d not settled, %d left", balances, i, b)
+			}
+		}
+	}
+}